@@ -1,9 +1,13 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
+	"github.com/fezcode/atlas.clock/pkg/cli"
+	"github.com/fezcode/atlas.clock/pkg/store"
 	"github.com/fezcode/atlas.clock/pkg/ui"
 )
 
@@ -13,34 +17,151 @@ func printHelp() {
 	fmt.Println("Atlas Clock — phosphor-CRT TUI for multi-timezone dashboards.")
 	fmt.Println()
 	fmt.Println("Usage:")
+	fmt.Println("  atlas.clock [--config FILE] [--board NAME] [--theme NAME] [--color WHEN] [command]")
+	fmt.Println()
 	fmt.Println("  atlas.clock          Start the clock dashboard")
+	fmt.Println("  atlas.clock now      Print every clock as plain text and exit")
+	fmt.Println("  atlas.clock convert \"2026-11-03 15:00\" --from Europe/Istanbul [--to ZONE,...]")
+	fmt.Println("                       Show a moment in every clock (or the --to zones)")
 	fmt.Println("  atlas.clock -v       Show version")
 	fmt.Println("  atlas.clock -h       Show this help")
 	fmt.Println()
+	fmt.Println("Manage clocks (CLOCK is a label or a grid index from `list`):")
+	fmt.Println("  atlas.clock list")
+	fmt.Println("  atlas.clock add --label \"NY Desk\" --zone America/New_York [--hours 09:00-17:00] [--group AMER] [--at N]")
+	fmt.Println("  atlas.clock remove CLOCK")
+	fmt.Println("  atlas.clock rename CLOCK --to LABEL")
+	fmt.Println("  atlas.clock move CLOCK --to N")
+	fmt.Println("  atlas.clock set CLOCK [--hours 09:00-17:00] [--weekend Fri,Sat] [--status ooo|on-call|NOTE] [--until YYYY-MM-DD] [--group NAME]")
+	fmt.Println()
+	fmt.Println("Boards (named dashboards; commands act on --board, else the active one):")
+	fmt.Println("  atlas.clock board list")
+	fmt.Println("  atlas.clock board add NAME")
+	fmt.Println("  atlas.clock board remove NAME")
+	fmt.Println("  atlas.clock board rename NAME --to NEW")
+	fmt.Println("  atlas.clock board use NAME")
+	fmt.Println("                       Make NAME the board the UI opens on")
+	fmt.Println()
+	fmt.Println("Config:")
+	fmt.Println("  atlas.clock config path")
+	fmt.Println("                       Show which config file is in effect and why")
+	fmt.Println("  atlas.clock config migrate [--dry-run]")
+	fmt.Println("                       Upgrade clock.json to the current schema version")
+	fmt.Println("  atlas.clock doctor")
+	fmt.Println("                       Check every clock for unknown zones and bad fields")
+	fmt.Println()
+	fmt.Println("Subcommands accept --format text|json|csv|tsv for machine-readable output.")
+	fmt.Println()
 	fmt.Println("Inside the UI:")
 	fmt.Println("  ↑↓←→/hjkl    navigate the grid")
+	fmt.Println("  PgUp/PgDn    scroll a screenful; Home/End jump to the first/last clock")
 	fmt.Println("  SHIFT+arrow  reorder the selected clock")
 	fmt.Println("  ↵            open the detail view")
 	fmt.Println("  a            add a clock (label → zone → confirm)")
+	fmt.Println("  e            edit the selected clock in place")
 	fmt.Println("  d            delete the selected clock")
+	fmt.Println("  [ ] / { }    time-travel every clock ±15m / ±1h")
+	fmt.Println("  n            snap back to live time")
+	fmt.Println("  u / ctrl+r   undo / redo add, edit, delete and reorder")
+	fmt.Println("  p            meeting planner (working-hours overlap)")
+	fmt.Println("  c            fold / unfold the selected clock's group")
+	fmt.Println("  v            cycle the grid / analog dials / compact list layouts")
+	fmt.Println("  TAB / 1-9    switch boards")
+	fmt.Println("  t            cycle color themes")
 	fmt.Println("  q            quit")
 	fmt.Println()
-	fmt.Println("Config: ~/.atlas/clock.json")
+	fmt.Println("Config file, first match wins:")
+	fmt.Println("  1. --config FILE")
+	fmt.Println("  2. $" + store.EnvConfig)
+	fmt.Println("  3. $XDG_CONFIG_HOME/atlas/clock.json (Linux; when present or XDG_CONFIG_HOME is set)")
+	fmt.Println("  4. ~/.atlas/clock.json")
+	fmt.Println("A missing file is seeded from /etc/atlas/clock.json (read-only) if present.")
+	fmt.Println()
+	fmt.Println("Themes: amber (default), green, ibm, paper, contrast, plus any themes/*.json")
+	fmt.Println("next to the config file. Pick one with --theme NAME or cycle with t; both")
+	fmt.Println("apply to the dashboard only. paper is for light terminal backgrounds.")
+	fmt.Println("--color auto|always|never (default auto, which honours NO_COLOR).")
+}
+
+// exitOnError reports a subcommand failure and exits non-zero. A bare -h on a
+// subcommand has already printed its usage, so it is not an error.
+func exitOnError(err error) {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
 }
 
 func main() {
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "-v", "--version":
-			fmt.Printf("atlas.clock v%s\n", Version)
+	// Global options come before the command.
+	fs := flag.NewFlagSet("atlas.clock", flag.ContinueOnError)
+	fs.Usage = printHelp
+	var showVersion bool
+	fs.BoolVar(&showVersion, "v", false, "show version")
+	fs.BoolVar(&showVersion, "version", false, "show version")
+	configPath := fs.String("config", "", "config file to use")
+	board := fs.String("board", "", "board to show or act on")
+	theme := fs.String("theme", "", "color theme for the dashboard (not subcommands)")
+	color := fs.String("color", "auto", "when to use color: auto, always or never")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
 			return
-		case "-h", "--help", "help":
+		}
+		os.Exit(2)
+	}
+	if showVersion {
+		fmt.Printf("atlas.clock v%s\n", Version)
+		return
+	}
+	if *configPath != "" {
+		store.SetConfigPath(*configPath)
+	}
+	if *board != "" {
+		store.SetBoard(*board)
+	}
+	if err := ui.SetColorMode(*color); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if args := fs.Args(); len(args) > 0 {
+		if *theme != "" {
+			fmt.Fprintln(os.Stderr, "Error: --theme only applies to the dashboard, not to subcommands")
+			os.Exit(2)
+		}
+		switch args[0] {
+		case "help":
 			printHelp()
-			return
+		case "now":
+			exitOnError(cli.Now(os.Stdout, args[1:]))
+		case "list", "ls":
+			exitOnError(cli.List(os.Stdout, args[1:]))
+		case "add":
+			exitOnError(cli.Add(os.Stdout, args[1:]))
+		case "remove", "rm":
+			exitOnError(cli.Remove(os.Stdout, args[1:]))
+		case "rename":
+			exitOnError(cli.Rename(os.Stdout, args[1:]))
+		case "set":
+			exitOnError(cli.Set(os.Stdout, args[1:]))
+		case "move", "mv":
+			exitOnError(cli.Move(os.Stdout, args[1:]))
+		case "board":
+			exitOnError(cli.Board(os.Stdout, args[1:]))
+		case "config":
+			exitOnError(cli.Config(os.Stdout, args[1:]))
+		case "convert":
+			exitOnError(cli.Convert(os.Stdout, args[1:]))
+		case "doctor":
+			exitOnError(cli.Doctor(os.Stdout, args[1:]))
+		default:
+			exitOnError(fmt.Errorf("unknown command %q (see atlas.clock -h)", args[0]))
 		}
+		return
 	}
 
-	if err := ui.Start(ui.Config{Version: Version}); err != nil {
+	if err := ui.Start(ui.Config{Version: Version, Theme: *theme}); err != nil {
 		fmt.Printf("Error starting UI: %v\n", err)
 		os.Exit(1)
 	}
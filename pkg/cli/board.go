@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// selectedClocks returns the clocks on the board in effect (--board, else the
+// active one).
+func selectedClocks(cfg store.Config) ([]store.Entry, error) {
+	name := cfg.Selected()
+	b := cfg.Board(name)
+	if b == nil {
+		return nil, fmt.Errorf("no board named %q (see `atlas.clock board list`)", name)
+	}
+	return b.Clocks, nil
+}
+
+// selectedBoard returns the board in effect for editing inside store.Update.
+// With create, a board that doesn't exist yet is added.
+func selectedBoard(cfg *store.Config, create bool) (*store.Board, error) {
+	name := cfg.Selected()
+	if b := cfg.Board(name); b != nil {
+		return b, nil
+	}
+	if !create {
+		return nil, fmt.Errorf("no board named %q (see `atlas.clock board list`)", name)
+	}
+	return cfg.AddBoard(name), nil
+}
+
+// boardList is the clock list of the selected board after a mutation.
+func boardList(cfg store.Config) listReport {
+	clocks, _ := selectedClocks(cfg)
+	return newListReport(clocks)
+}
+
+// Board dispatches the `board` subcommands.
+func Board(w io.Writer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("board: expected a subcommand: list, add, remove, rename or use")
+	}
+	switch args[0] {
+	case "list", "ls":
+		return boardListCmd(w, args[1:])
+	case "add":
+		return boardAdd(w, args[1:])
+	case "remove", "rm":
+		return boardRemove(w, args[1:])
+	case "rename":
+		return boardRename(w, args[1:])
+	case "use":
+		return boardUse(w, args[1:])
+	}
+	return fmt.Errorf("board: unknown subcommand %q", args[0])
+}
+
+// boardRecord is one row of `board list` output.
+type boardRecord struct {
+	Name     string `json:"name"`
+	Clocks   int    `json:"clocks"`
+	Selected bool   `json:"selected"`
+}
+
+type boardReport []boardRecord
+
+func newBoardReport(cfg store.Config) boardReport {
+	sel := cfg.Selected()
+	r := make(boardReport, len(cfg.Boards))
+	for i, b := range cfg.Boards {
+		r[i] = boardRecord{Name: b.Name, Clocks: len(b.Clocks), Selected: b.Name == sel}
+	}
+	return r
+}
+
+func (r boardReport) header() []string { return []string{"name", "clocks", "selected"} }
+
+func (r boardReport) rows() [][]string {
+	out := make([][]string, len(r))
+	for i, b := range r {
+		out[i] = []string{b.Name, strconv.Itoa(b.Clocks), strconv.FormatBool(b.Selected)}
+	}
+	return out
+}
+
+func (r boardReport) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, b := range r {
+		mark := " "
+		if b.Selected {
+			mark = "*"
+		}
+		noun := "clocks"
+		if b.Clocks == 1 {
+			noun = "clock"
+		}
+		fmt.Fprintf(tw, "%s %s\t%d %s\n", mark, b.Name, b.Clocks, noun)
+	}
+	return tw.Flush()
+}
+
+// boardListCmd prints every board, marking the one in effect.
+func boardListCmd(w io.Writer, args []string) error {
+	fs := newFlagSet("board list")
+	f := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, newBoardReport(cfg))
+}
+
+// boardName validates a board name given on the command line.
+func boardName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("board name must not be empty")
+	}
+	return name, nil
+}
+
+// findBoard looks a board up for a `board` subcommand.
+func findBoard(cfg *store.Config, name string) (*store.Board, error) {
+	b := cfg.Board(name)
+	if b == nil {
+		return nil, fmt.Errorf("no board named %q", name)
+	}
+	return b, nil
+}
+
+// boardAdd creates an empty board.
+func boardAdd(w io.Writer, args []string) error {
+	fs := newFlagSet("board add")
+	f := formatFlag(fs)
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	name, err := boardName(pos[0])
+	if err != nil {
+		return fmt.Errorf("board add: %w", err)
+	}
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		if cfg.Board(name) != nil {
+			return fmt.Errorf("board add: %q already exists", name)
+		}
+		cfg.AddBoard(name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("added board %q", name),
+		list:    newBoardReport(cfg),
+	})
+}
+
+// boardRemove deletes a board and its clocks. The last board can't go.
+func boardRemove(w io.Writer, args []string) error {
+	fs := newFlagSet("board remove")
+	f := formatFlag(fs)
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	name := pos[0]
+	var removed store.Board
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		i := slices.IndexFunc(cfg.Boards, func(b store.Board) bool { return b.Name == name })
+		if i < 0 {
+			return fmt.Errorf("board remove: no board named %q", name)
+		}
+		if len(cfg.Boards) == 1 {
+			return fmt.Errorf("board remove: %q is the only board", name)
+		}
+		removed = cfg.Boards[i]
+		cfg.Boards = append(cfg.Boards[:i], cfg.Boards[i+1:]...)
+		if cfg.Active == name {
+			cfg.Active = ""
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("removed board %q (%d clocks)", removed.Name, len(removed.Clocks)),
+		list:    newBoardReport(cfg),
+	})
+}
+
+// boardRename renames a board, following it if it was the active one.
+func boardRename(w io.Writer, args []string) error {
+	fs := newFlagSet("board rename")
+	f := formatFlag(fs)
+	to := fs.String("to", "", "new board name (required)")
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	name, err := boardName(*to)
+	if err != nil {
+		return fmt.Errorf("board rename: --to: %w", err)
+	}
+	old := pos[0]
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := findBoard(cfg, old)
+		if err != nil {
+			return fmt.Errorf("board rename: %w", err)
+		}
+		if name != old && cfg.Board(name) != nil {
+			return fmt.Errorf("board rename: %q already exists", name)
+		}
+		b.Name = name
+		if cfg.Active == old {
+			cfg.Active = name
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("renamed board %q to %q", old, name),
+		list:    newBoardReport(cfg),
+	})
+}
+
+// boardUse makes a board the active one: the dashboard opens on it and
+// commands act on it when --board isn't given.
+func boardUse(w io.Writer, args []string) error {
+	fs := newFlagSet("board use")
+	f := formatFlag(fs)
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	name := pos[0]
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		if _, err := findBoard(cfg, name); err != nil {
+			return fmt.Errorf("board use: %w", err)
+		}
+		cfg.Active = name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("now using board %q", name),
+		list:    newBoardReport(cfg),
+	})
+}
@@ -0,0 +1,50 @@
+// Package cli implements the non-interactive atlas.clock subcommands. Every
+// command writes plain text to the given writer so it can be used from
+// scripts, SSH sessions and CI logs without the alt screen.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// newFlagSet returns a flag set that reports errors instead of exiting, so
+// main can decide on the exit code.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// noArgs rejects stray positional arguments left over after flag parsing.
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%s: unexpected argument %q", fs.Name(), fs.Arg(0))
+	}
+	return nil
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments (`move "NY Desk" --to 0`) and requires exactly want positionals.
+func parseInterspersed(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+	if len(pos) != want {
+		if want == 0 {
+			return nil, fmt.Errorf("%s: unexpected argument %q", fs.Name(), pos[0])
+		}
+		return nil, fmt.Errorf("%s: expected %d argument(s), got %d", fs.Name(), want, len(pos))
+	}
+	return pos, nil
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// Config dispatches the `config` subcommands.
+func Config(w io.Writer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("config: expected a subcommand: path or migrate")
+	}
+	switch args[0] {
+	case "path":
+		return configPath(w, args[1:])
+	case "migrate":
+		return configMigrate(w, args[1:])
+	}
+	return fmt.Errorf("config: unknown subcommand %q", args[0])
+}
+
+// pathReport is the output of `config path`.
+type pathReport struct {
+	store.Location
+	Exists       bool `json:"exists"`
+	SystemExists bool `json:"system_exists"`
+}
+
+func (r pathReport) header() []string {
+	return []string{"path", "source", "exists", "system", "system_exists"}
+}
+
+func (r pathReport) rows() [][]string {
+	return [][]string{{
+		r.Path, string(r.Source), strconv.FormatBool(r.Exists),
+		r.System, strconv.FormatBool(r.SystemExists),
+	}}
+}
+
+func (r pathReport) writeText(w io.Writer) error {
+	fmt.Fprintf(w, "%s  (from %s)\n", r.Path, r.Source)
+	if !r.Exists && r.SystemExists {
+		fmt.Fprintf(w, "not created yet — reading the system default %s until the first save\n", r.System)
+	} else if !r.Exists {
+		fmt.Fprintln(w, "not created yet — using the built-in default dashboard until the first save")
+	}
+	return nil
+}
+
+// configPath prints which config file is in effect and why.
+func configPath(w io.Writer, args []string) error {
+	fs := newFlagSet("config path")
+	f := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	loc, err := store.Resolve()
+	if err != nil {
+		return err
+	}
+	r := pathReport{Location: loc, Exists: fileExists(loc.Path)}
+	if loc.System != "" {
+		r.SystemExists = fileExists(loc.System)
+	}
+	return emit(w, *f, r)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// migrateReport is the output of `config migrate`.
+type migrateReport struct {
+	Path    string   `json:"path"`
+	DryRun  bool     `json:"dry_run"`
+	Version int      `json:"version"`
+	Steps   []string `json:"steps"`
+
+	before, after []byte
+}
+
+func (r migrateReport) header() []string { return []string{"step"} }
+
+func (r migrateReport) rows() [][]string {
+	out := make([][]string, len(r.Steps))
+	for i, s := range r.Steps {
+		out[i] = []string{s}
+	}
+	return out
+}
+
+func (r migrateReport) writeText(w io.Writer) error {
+	if len(r.Steps) == 0 {
+		_, err := fmt.Fprintf(w, "%s is already at version %d\n", r.Path, r.Version)
+		return err
+	}
+	for _, s := range r.Steps {
+		fmt.Fprintln(w, s)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "--- %s\n+++ %s (version %d)\n", r.Path, r.Path, r.Version)
+	writeDiff(w, string(r.before), string(r.after))
+	if r.DryRun {
+		_, err := fmt.Fprintln(w, "\ndry run — nothing written")
+		return err
+	}
+	return nil
+}
+
+// configMigrate upgrades the config file to the current schema version.
+func configMigrate(w io.Writer, args []string) error {
+	fs := newFlagSet("config migrate")
+	f := formatFlag(fs)
+	dryRun := fs.Bool("dry-run", false, "show the changes without writing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	path, err := store.ConfigPath()
+	if err != nil {
+		return err
+	}
+	before, after, steps, err := store.MigrateFile(*dryRun)
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, migrateReport{
+		Path:    path,
+		DryRun:  *dryRun,
+		Version: store.CurrentVersion,
+		Steps:   append([]string{}, steps...),
+		before:  before,
+		after:   after,
+	})
+}
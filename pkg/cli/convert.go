@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// inputLayouts are the wall-clock formats accepted by `convert`, tried in order.
+// Layouts without a date are taken as today in the source zone.
+var inputLayouts = []struct {
+	layout  string
+	hasDate bool
+}{
+	{"2006-01-02 15:04", true},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02T15:04", true},
+	{"2006-01-02T15:04:05", true},
+	{"15:04", false},
+	{"15:04:05", false},
+}
+
+// convertRecord is one target zone of a conversion.
+type convertRecord struct {
+	clockRecord
+	DayDelta int `json:"day_delta"`
+}
+
+// convertReport is the output of `convert`.
+type convertReport struct {
+	Source  clockRecord     `json:"source"`
+	Warning string          `json:"warning,omitempty"`
+	Targets []convertRecord `json:"targets"`
+}
+
+func (r convertReport) header() []string {
+	return append(clockReport{}.header(), "day_delta")
+}
+
+func (r convertReport) rows() [][]string {
+	out := make([][]string, len(r.Targets))
+	for i, t := range r.Targets {
+		out[i] = append(clockReport{t.clockRecord}.rows()[0], strconv.Itoa(t.DayDelta))
+	}
+	return out
+}
+
+func (r convertReport) writeText(w io.Writer) error {
+	src := r.Source.at
+	fmt.Fprintf(w, "%s  %s  %s\n",
+		r.Source.Location,
+		src.Format("Mon 02 Jan 2006 15:04"),
+		store.FormatOffset(r.Source.OffsetSeconds),
+	)
+	if r.Warning != "" {
+		fmt.Fprintf(w, "! %s\n", r.Warning)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, t := range r.Targets {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s",
+			t.Phase.Glyph(),
+			t.Label,
+			t.Location,
+			t.at.Format("Mon 02 Jan 15:04"),
+			store.FormatOffset(t.OffsetSeconds),
+		)
+		if t.DayDelta != 0 {
+			fmt.Fprintf(tw, "\t%s", dayDelta(t.DayDelta))
+		}
+		if t.Error != "" {
+			fmt.Fprintf(tw, "\t! %s — local time shown", t.Error)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// Convert prints the wall-clock time in each target zone for a moment given
+// in the --from zone, flagging day changes and DST gaps/overlaps.
+func Convert(w io.Writer, args []string) error {
+	fs := newFlagSet("convert")
+	f := formatFlag(fs)
+	from := fs.String("from", "Local", "zone the input time is expressed in")
+	var to []string
+	fs.Func("to", "target zone or clock label; repeatable or comma-separated (default: every clock)", func(s string) error {
+		for _, part := range strings.Split(s, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				to = append(to, part)
+			}
+		}
+		return nil
+	})
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	srcEntry := store.Entry{Label: *from, Location: *from}
+	loc, err := srcEntry.Zone()
+	if err != nil {
+		return fmt.Errorf("convert: --from: %w", err)
+	}
+	instant, warning, err := parseInstant(pos[0], loc)
+	if err != nil {
+		return fmt.Errorf("convert: %w", err)
+	}
+
+	targets, err := convertTargets(to)
+	if err != nil {
+		return fmt.Errorf("convert: %w", err)
+	}
+
+	src := newClockRecord(srcEntry, instant)
+	r := convertReport{Source: src, Warning: warning}
+	for _, e := range targets {
+		t := newClockRecord(e, instant)
+		r.Targets = append(r.Targets, convertRecord{clockRecord: t, DayDelta: daysBetween(src.at, t.at)})
+	}
+	if warning != "" && (*f == formatCSV || *f == formatTSV) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	return emit(w, *f, r)
+}
+
+// convertTargets resolves --to values against the selected board (by
+// label) and the zone database, defaulting to every clock on it.
+func convertTargets(to []string) ([]store.Entry, error) {
+	cfg, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	clocks, err := selectedClocks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(to) == 0 {
+		return clocks, nil
+	}
+	var out []store.Entry
+next:
+	for _, name := range to {
+		for _, e := range clocks {
+			if strings.EqualFold(e.Label, name) {
+				out = append(out, e)
+				continue next
+			}
+		}
+		if err := validateZone(name); err != nil {
+			return nil, fmt.Errorf("--to: %w", err)
+		}
+		out = append(out, store.Entry{Label: name, Location: name})
+	}
+	return out, nil
+}
+
+// parseInstant reads a wall-clock time in loc. The returned warning is set
+// when the time falls in a DST gap (it does not exist and was shifted) or an
+// overlap (it happens twice; the first occurrence is used).
+func parseInstant(s string, loc *time.Location) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "now" {
+		return time.Now().In(loc), "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, "", nil
+	}
+	for _, l := range inputLayouts {
+		wall, err := time.Parse(l.layout, s)
+		if err != nil {
+			continue
+		}
+		if !l.hasDate {
+			y, m, d := time.Now().In(loc).Date()
+			wall = time.Date(y, m, d, wall.Hour(), wall.Minute(), wall.Second(), 0, time.UTC)
+		}
+		t, warning := resolveWall(wall, loc)
+		return t, warning, nil
+	}
+	return time.Time{}, "", errors.New(`cannot parse time; use "2006-01-02 15:04", "15:04" or RFC3339`)
+}
+
+// resolveWall maps the wall-clock fields of wall (read as UTC) onto loc. A
+// time in a DST gap is moved forward by the length of the gap — 02:30 on a
+// spring-forward night becomes 03:30 — since time.Date may resolve it either
+// way.
+func resolveWall(wall time.Time, loc *time.Location) (time.Time, string) {
+	y, mo, d := wall.Date()
+	h, mi, sec := wall.Clock()
+	t := time.Date(y, mo, d, h, mi, sec, 0, loc)
+
+	if t.Hour() != h || t.Minute() != mi {
+		// Reading the wall clock with the offset from before the
+		// transition lands the gap's length after it.
+		_, before := t.Add(-12 * time.Hour).Zone()
+		t = wall.Add(-time.Duration(before) * time.Second).In(loc)
+		return t, fmt.Sprintf("%s does not exist in %s (DST gap); using %s",
+			wall.Format("2006-01-02 15:04"), loc, t.Format("15:04 MST"))
+	}
+
+	// An overlap shows up as a second offset, taken from either side of the
+	// transition, that maps the same wall clock to a different instant.
+	for _, probe := range []time.Time{t.Add(-12 * time.Hour), t.Add(12 * time.Hour)} {
+		_, off := probe.Zone()
+		alt := wall.Add(-time.Duration(off) * time.Second).In(loc)
+		if alt.Equal(t) || alt.Hour() != h || alt.Minute() != mi {
+			continue
+		}
+		first, second := t, alt
+		if second.Before(first) {
+			first, second = second, first
+		}
+		return first, fmt.Sprintf("%s is ambiguous in %s (DST overlap): %s or %s; using the first",
+			wall.Format("2006-01-02 15:04"), loc, first.Format("15:04 MST"), second.Format("15:04 MST"))
+	}
+	return t, ""
+}
+
+// daysBetween is the calendar-day difference between two wall clocks.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func dayDelta(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestResolveWall(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		wall    string
+		want    string // RFC 3339, UTC
+		warning string // substring; "" for none
+	}{
+		{name: "plain", zone: "Europe/Istanbul", wall: "2026-07-15 12:00", want: "2026-07-15T09:00:00Z"},
+		{name: "half hour zone", zone: "Asia/Kolkata", wall: "2026-07-15 12:00", want: "2026-07-15T06:30:00Z"},
+		// New York springs forward at 02:00 on 8 March 2026.
+		{name: "us gap", zone: "America/New_York", wall: "2026-03-08 02:30", want: "2026-03-08T07:30:00Z", warning: "using 03:30 EDT"},
+		{name: "eu gap", zone: "Europe/Berlin", wall: "2026-03-29 02:30", want: "2026-03-29T01:30:00Z", warning: "using 03:30 CEST"},
+		// Lord Howe's gap is half an hour, 02:00 to 02:30.
+		{name: "half hour gap", zone: "Australia/Lord_Howe", wall: "2026-10-04 02:15", want: "2026-10-03T15:45:00Z", warning: "using 02:45"},
+		{name: "just after gap", zone: "America/New_York", wall: "2026-03-08 03:00", want: "2026-03-08T07:00:00Z"},
+		// New York falls back at 02:00 on 1 November 2026; 01:30 happens twice.
+		{name: "us overlap", zone: "America/New_York", wall: "2026-11-01 01:30", want: "2026-11-01T05:30:00Z", warning: "01:30 EDT or 01:30 EST; using the first"},
+		{name: "eu overlap", zone: "Europe/Berlin", wall: "2026-10-25 02:30", want: "2026-10-25T00:30:00Z", warning: "02:30 CEST or 02:30 CET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wall, err := time.Parse("2006-01-02 15:04", tt.wall)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, warning := resolveWall(wall, mustZone(t, tt.zone))
+			if s := got.UTC().Format(time.RFC3339); s != tt.want {
+				t.Errorf("resolveWall(%s) = %s, want %s", tt.wall, s, tt.want)
+			}
+			if tt.warning == "" && warning != "" {
+				t.Errorf("unexpected warning %q", warning)
+			}
+			if !strings.Contains(warning, tt.warning) {
+				t.Errorf("warning = %q, want it to contain %q", warning, tt.warning)
+			}
+		})
+	}
+}
+
+func TestParseInstant(t *testing.T) {
+	tests := []struct {
+		zone    string // Europe/Istanbul if empty
+		in      string
+		want    string // RFC 3339, UTC; "" for an error
+		warning bool
+	}{
+		{in: "2026-11-03 15:00", want: "2026-11-03T12:00:00Z"},
+		{in: "2026-11-03 15:00:30", want: "2026-11-03T12:00:30Z"},
+		{in: "2026-11-03T15:00", want: "2026-11-03T12:00:00Z"},
+		{in: "  2026-11-03 15:00  ", want: "2026-11-03T12:00:00Z"},
+		// RFC 3339 carries its own offset; the source zone is ignored.
+		{in: "2026-11-03T15:00:00+09:00", want: "2026-11-03T06:00:00Z"},
+		{in: "tomorrow", want: ""},
+		{in: "2026-11-03 25:00", want: ""},
+		{zone: "America/New_York", in: "2026-03-08 02:30", want: "2026-03-08T07:30:00Z", warning: true},
+		{zone: "America/New_York", in: "2026-11-01 01:30", want: "2026-11-01T05:30:00Z", warning: true},
+	}
+	istanbul := mustZone(t, "Europe/Istanbul")
+	for _, tt := range tests {
+		loc := istanbul
+		if tt.zone != "" {
+			loc = mustZone(t, tt.zone)
+		}
+		got, warning, err := parseInstant(tt.in, loc)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("parseInstant(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseInstant(%q): %v", tt.in, err)
+			continue
+		}
+		if s := got.UTC().Format(time.RFC3339); s != tt.want {
+			t.Errorf("parseInstant(%q) = %s, want %s", tt.in, s, tt.want)
+		}
+		if (warning != "") != tt.warning {
+			t.Errorf("parseInstant(%q) warning = %q", tt.in, warning)
+		}
+	}
+
+	// A time of day alone is today in the source zone.
+	got, _, err := parseInstant("15:04", istanbul)
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().In(istanbul).Format("2006-01-02")
+	if s := got.In(istanbul).Format("2006-01-02 15:04"); s != today+" 15:04" {
+		t.Errorf("parseInstant(%q) = %s, want %s 15:04", "15:04", s, today)
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// doctorRecord is one problem found by `doctor`.
+type doctorRecord struct {
+	Board      string `json:"board"`
+	Index      int    `json:"index"`
+	Label      string `json:"label"`
+	Field      string `json:"field"`
+	Value      string `json:"value"`
+	Problem    string `json:"problem"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// doctorReport is the output of `doctor`. The counts are for the text
+// summary; structured formats carry only the problems.
+type doctorReport struct {
+	problems       []doctorRecord
+	path           string
+	clocks, boards int
+}
+
+func newDoctorReport(cfg store.Config, path string) doctorReport {
+	r := doctorReport{problems: []doctorRecord{}, path: path, boards: len(cfg.Boards)}
+	for _, b := range cfg.Boards {
+		r.clocks += len(b.Clocks)
+	}
+	for _, p := range cfg.Check() {
+		r.problems = append(r.problems, doctorRecord{
+			Board:      p.Board,
+			Index:      p.Index,
+			Label:      p.Entry.Label,
+			Field:      p.Field,
+			Value:      p.Value,
+			Problem:    p.Err.Error(),
+			Suggestion: p.Suggestion,
+		})
+	}
+	return r
+}
+
+func (r doctorReport) header() []string {
+	return []string{"board", "index", "label", "field", "value", "problem", "suggestion"}
+}
+
+func (r doctorReport) rows() [][]string {
+	out := make([][]string, len(r.problems))
+	for i, p := range r.problems {
+		out[i] = []string{p.Board, strconv.Itoa(p.Index), p.Label, p.Field, p.Value, p.Problem, p.Suggestion}
+	}
+	return out
+}
+
+func (r doctorReport) MarshalJSON() ([]byte, error) { return json.Marshal(r.problems) }
+
+func (r doctorReport) writeText(w io.Writer) error {
+	if len(r.problems) == 0 {
+		_, err := fmt.Fprintf(w, "no problems found in %s (%d clock(s) on %d board(s))\n", r.path, r.clocks, r.boards)
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, p := range r.problems {
+		fmt.Fprintf(tw, "%s\t#%d\t%s\t%s", p.Board, p.Index, p.Label, p.Problem)
+		if p.Suggestion != "" {
+			fmt.Fprintf(tw, " — did you mean %s?", p.Suggestion)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// Doctor checks every clock on every board — zone names, working hours,
+// weekends and status expiry dates — and reports what won't work as
+// written, with a suggested zone for near-miss names. Problems make it
+// fail, so it can gate a dotfiles sync.
+func Doctor(w io.Writer, args []string) error {
+	fs := newFlagSet("doctor")
+	f := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	path, err := store.ConfigPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return err
+	}
+	r := newDoctorReport(cfg, path)
+	if err := emit(w, *f, r); err != nil {
+		return err
+	}
+	if n := len(r.problems); n > 0 {
+		return fmt.Errorf("doctor: %d problem(s) found", n)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// format selects how a command serializes its output.
+type format string
+
+const (
+	formatText format = "text"
+	formatJSON format = "json"
+	formatCSV  format = "csv"
+	formatTSV  format = "tsv"
+)
+
+func (f *format) String() string { return string(*f) }
+
+func (f *format) Set(s string) error {
+	switch v := format(strings.ToLower(s)); v {
+	case formatText, formatJSON, formatCSV, formatTSV:
+		*f = v
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want text, json, csv or tsv)", s)
+}
+
+// formatFlag registers --format on fs, defaulting to text.
+func formatFlag(fs *flag.FlagSet) *format {
+	f := formatText
+	fs.Var(&f, "format", "output format: text, json, csv or tsv")
+	return &f
+}
+
+// report is a command result that can be written in every output format.
+// JSON encodes the report value itself; CSV and TSV use header and rows.
+type report interface {
+	header() []string
+	rows() [][]string
+	writeText(w io.Writer) error
+}
+
+// emit writes r to w in the requested format.
+func emit(w io.Writer, f format, r report) error {
+	switch f {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(r.header()); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(r.rows()); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTSV:
+		// TSV has no quoting; flatten the separators out of each field instead.
+		clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+		for _, row := range append([][]string{r.header()}, r.rows()...) {
+			for i := range row {
+				row[i] = clean.Replace(row[i])
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return r.writeText(w)
+	}
+}
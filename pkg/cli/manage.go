@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// listRecord is one row of `list` output.
+type listRecord struct {
+	Index    int    `json:"index"`
+	Label    string `json:"label"`
+	Location string `json:"location"`
+	Group    string `json:"group,omitempty"`
+}
+
+// listReport is the output of `list` and the trailing state of every
+// mutating command.
+type listReport []listRecord
+
+func newListReport(clocks []store.Entry) listReport {
+	r := make(listReport, len(clocks))
+	for i, e := range clocks {
+		r[i] = listRecord{Index: i, Label: e.Label, Location: e.Location, Group: e.Group}
+	}
+	return r
+}
+
+func (r listReport) header() []string { return []string{"index", "label", "location", "group"} }
+
+func (r listReport) rows() [][]string {
+	out := make([][]string, len(r))
+	for i, c := range r {
+		out[i] = []string{strconv.Itoa(c.Index), c.Label, c.Location, c.Group}
+	}
+	return out
+}
+
+func (r listReport) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r {
+		fmt.Fprintf(tw, "%d\t%s\t%s", c.Index, c.Label, c.Location)
+		if c.Group != "" {
+			fmt.Fprintf(tw, "\t[%s]", c.Group)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// changeReport prints a one-line confirmation in text mode and the resulting
+// clock (or board) list in every structured format.
+type changeReport struct {
+	message string
+	list    report
+}
+
+func (r changeReport) header() []string             { return r.list.header() }
+func (r changeReport) rows() [][]string             { return r.list.rows() }
+func (r changeReport) MarshalJSON() ([]byte, error) { return json.Marshal(r.list) }
+
+func (r changeReport) writeText(w io.Writer) error {
+	_, err := fmt.Fprintln(w, r.message)
+	return err
+}
+
+// List prints the selected board's clocks with their grid index.
+func List(w io.Writer, args []string) error {
+	fs := newFlagSet("list")
+	f := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return err
+	}
+	clocks, err := selectedClocks(cfg)
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, newListReport(clocks))
+}
+
+// Add appends a clock, or inserts it at --at.
+func Add(w io.Writer, args []string) error {
+	fs := newFlagSet("add")
+	f := formatFlag(fs)
+	label := fs.String("label", "", "label shown on the card (required)")
+	zone := fs.String("zone", "", "IANA zone name, e.g. America/New_York (required)")
+	hours := fs.String("hours", "", "working hours, e.g. 09:00-17:00 (default "+store.DefaultHours+")")
+	group := fs.String("group", "", "dashboard section, e.g. EMEA")
+	at := fs.Int("at", -1, "grid index to insert at (default: end)")
+	if _, err := parseInterspersed(fs, args, 0); err != nil {
+		return err
+	}
+	e := store.Entry{
+		Label:    strings.TrimSpace(*label),
+		Location: strings.TrimSpace(*zone),
+		Hours:    strings.TrimSpace(*hours),
+		Group:    strings.TrimSpace(*group),
+	}
+	if e.Label == "" {
+		return errors.New("add: --label is required")
+	}
+	if err := validateZone(e.Location); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+	if e.Hours != "" {
+		if _, err := store.ParseHours(e.Hours); err != nil {
+			return fmt.Errorf("add: %w", err)
+		}
+	}
+
+	var pos int
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := selectedBoard(cfg, true)
+		if err != nil {
+			return err
+		}
+		pos = len(b.Clocks)
+		if *at >= 0 && *at < pos {
+			pos = *at
+		}
+		b.Clocks = append(b.Clocks[:pos], append([]store.Entry{e}, b.Clocks[pos:]...)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("added %q (%s) at #%d", e.Label, e.Location, pos),
+		list:    boardList(cfg),
+	})
+}
+
+// Remove deletes the clock named by label or index.
+func Remove(w io.Writer, args []string) error {
+	fs := newFlagSet("remove")
+	f := formatFlag(fs)
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	var e store.Entry
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := selectedBoard(cfg, false)
+		if err != nil {
+			return fmt.Errorf("remove: %w", err)
+		}
+		i, err := findClock(b.Clocks, pos[0])
+		if err != nil {
+			return fmt.Errorf("remove: %w", err)
+		}
+		e = b.Clocks[i]
+		b.Clocks = append(b.Clocks[:i], b.Clocks[i+1:]...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("removed %q (%s)", e.Label, e.Location),
+		list:    boardList(cfg),
+	})
+}
+
+// Rename changes the label of the clock named by label or index.
+func Rename(w io.Writer, args []string) error {
+	fs := newFlagSet("rename")
+	f := formatFlag(fs)
+	to := fs.String("to", "", "new label (required)")
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	label := strings.TrimSpace(*to)
+	if label == "" {
+		return errors.New("rename: --to is required")
+	}
+
+	var old string
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := selectedBoard(cfg, false)
+		if err != nil {
+			return fmt.Errorf("rename: %w", err)
+		}
+		i, err := findClock(b.Clocks, pos[0])
+		if err != nil {
+			return fmt.Errorf("rename: %w", err)
+		}
+		old = b.Clocks[i].Label
+		b.Clocks[i].Label = label
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("renamed %q to %q", old, label),
+		list:    boardList(cfg),
+	})
+}
+
+// Set updates the working hours, weekend, status and group of the clock
+// named by label or index. Only flags given on the command line are changed;
+// an empty value clears the field back to its default.
+func Set(w io.Writer, args []string) error {
+	fs := newFlagSet("set")
+	f := formatFlag(fs)
+	hours := fs.String("hours", "", "working hours, e.g. 09:00-12:00,13:00-18:00")
+	weekend := fs.String("weekend", "", `weekend days, e.g. Fri,Sat, or "none"`)
+	status := fs.String("status", "", `"ooo", "on-call" or a free-text note`)
+	until := fs.String("until", "", "date the status lapses on, YYYY-MM-DD")
+	group := fs.String("group", "", "dashboard section, e.g. EMEA")
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	given := map[string]bool{}
+	fs.Visit(func(fl *flag.Flag) { given[fl.Name] = true })
+	if *hours != "" {
+		if _, err := store.ParseHours(*hours); err != nil {
+			return fmt.Errorf("set: %w", err)
+		}
+	}
+	if *weekend != "" {
+		if _, err := store.ParseWeekend(*weekend); err != nil {
+			return fmt.Errorf("set: %w", err)
+		}
+	}
+	if *until != "" {
+		if _, err := store.ParseUntil(*until); err != nil {
+			return fmt.Errorf("set: %w", err)
+		}
+	}
+
+	var e store.Entry
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := selectedBoard(cfg, false)
+		if err != nil {
+			return fmt.Errorf("set: %w", err)
+		}
+		i, err := findClock(b.Clocks, pos[0])
+		if err != nil {
+			return fmt.Errorf("set: %w", err)
+		}
+		c := &b.Clocks[i]
+		if given["hours"] {
+			c.Hours = strings.TrimSpace(*hours)
+		}
+		if given["weekend"] {
+			c.Weekend = strings.TrimSpace(*weekend)
+		}
+		if given["status"] {
+			c.Status = strings.TrimSpace(*status)
+		}
+		if given["until"] {
+			c.Until = strings.TrimSpace(*until)
+		}
+		if given["group"] {
+			c.Group = strings.TrimSpace(*group)
+		}
+		e = *c
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("updated %q: now %s", e.Label, e.AvailabilityAt(time.Now())),
+		list:    boardList(cfg),
+	})
+}
+
+// Move relocates the clock named by label or index to grid index --to.
+func Move(w io.Writer, args []string) error {
+	fs := newFlagSet("move")
+	f := formatFlag(fs)
+	to := fs.Int("to", -1, "destination grid index (required)")
+	pos, err := parseInterspersed(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	var e store.Entry
+	cfg, err := store.Update(func(cfg *store.Config) error {
+		b, err := selectedBoard(cfg, false)
+		if err != nil {
+			return fmt.Errorf("move: %w", err)
+		}
+		i, err := findClock(b.Clocks, pos[0])
+		if err != nil {
+			return fmt.Errorf("move: %w", err)
+		}
+		if *to < 0 || *to >= len(b.Clocks) {
+			return fmt.Errorf("move: --to must be between 0 and %d", len(b.Clocks)-1)
+		}
+		e = b.Clocks[i]
+		rest := append(b.Clocks[:i:i], b.Clocks[i+1:]...)
+		b.Clocks = append(rest[:*to:*to], append([]store.Entry{e}, rest[*to:]...)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return emit(w, *f, changeReport{
+		message: fmt.Sprintf("moved %q to #%d", e.Label, *to),
+		list:    boardList(cfg),
+	})
+}
+
+// findClock resolves a target given on the command line: an exact label
+// (case-insensitive) wins, otherwise the target is read as a grid index.
+func findClock(clocks []store.Entry, target string) (int, error) {
+	match := -1
+	for i, e := range clocks {
+		if strings.EqualFold(e.Label, target) {
+			if match >= 0 {
+				return 0, fmt.Errorf("label %q is ambiguous; use the index from `list`", target)
+			}
+			match = i
+		}
+	}
+	if match >= 0 {
+		return match, nil
+	}
+	if i, err := strconv.Atoi(target); err == nil {
+		if i < 0 || i >= len(clocks) {
+			return 0, fmt.Errorf("index %d out of range (%d clocks)", i, len(clocks))
+		}
+		return i, nil
+	}
+	return 0, fmt.Errorf("no clock labelled %q", target)
+}
+
+// validateZone rejects zone names the runtime cannot load, suggesting the
+// closest known one.
+func validateZone(name string) error {
+	if name == "" {
+		return errors.New("--zone is required")
+	}
+	_, err := store.LoadZone(name)
+	if err != nil {
+		if s := store.SuggestZone(name); s != "" {
+			return fmt.Errorf("%w (did you mean %s?)", err, s)
+		}
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// clockRecord is the serialized state of one clock at a given instant.
+type clockRecord struct {
+	Label         string             `json:"label"`
+	Location      string             `json:"location"`
+	Time          string             `json:"time"`
+	Unix          int64              `json:"unix"`
+	OffsetSeconds int                `json:"offset_seconds"`
+	Abbreviation  string             `json:"abbreviation"`
+	DST           bool               `json:"is_dst"`
+	Phase         store.Phase        `json:"phase"`
+	Availability  store.Availability `json:"availability"`
+	// Error is set when the zone can't be resolved; Time is then local.
+	Error string `json:"error,omitempty"`
+
+	at time.Time
+}
+
+func newClockRecord(e store.Entry, now time.Time) clockRecord {
+	t := e.At(now)
+	abbr, off := t.Zone()
+	var zoneErr string
+	if _, err := e.Zone(); err != nil {
+		zoneErr = err.Error()
+	}
+	return clockRecord{
+		Label:         e.Label,
+		Location:      e.Location,
+		Time:          t.Format(time.RFC3339),
+		Unix:          t.Unix(),
+		OffsetSeconds: off,
+		Abbreviation:  abbr,
+		DST:           t.IsDST(),
+		Phase:         store.PhaseAt(t.Hour()),
+		Availability:  e.AvailabilityAt(now),
+		Error:         zoneErr,
+		at:            t,
+	}
+}
+
+// clockReport is the output of `now`.
+type clockReport []clockRecord
+
+func (r clockReport) header() []string {
+	return []string{"label", "location", "time", "unix", "offset_seconds", "abbreviation", "is_dst", "phase", "availability", "error"}
+}
+
+func (r clockReport) rows() [][]string {
+	out := make([][]string, len(r))
+	for i, c := range r {
+		out[i] = []string{
+			c.Label,
+			c.Location,
+			c.Time,
+			strconv.FormatInt(c.Unix, 10),
+			strconv.Itoa(c.OffsetSeconds),
+			c.Abbreviation,
+			strconv.FormatBool(c.DST),
+			string(c.Phase),
+			string(c.Availability),
+			c.Error,
+		}
+	}
+	return out
+}
+
+func (r clockReport) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r {
+		status := string(c.Availability)
+		if c.Error != "" {
+			status = "! " + c.Error + " — local time shown"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Phase.Glyph(),
+			c.Label,
+			c.Location,
+			c.at.Format("15:04:05"),
+			c.at.Format("Mon 02 Jan"),
+			store.FormatOffset(c.OffsetSeconds),
+			status,
+		)
+	}
+	return tw.Flush()
+}
+
+// Now prints every clock on the selected board — glyph, label, zone, time, offset — one
+// per line, or as JSON/CSV/TSV records with --format.
+func Now(w io.Writer, args []string) error {
+	fs := newFlagSet("now")
+	f := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	cfg, err := store.Load()
+	if err != nil {
+		return err
+	}
+	clocks, err := selectedClocks(cfg)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	r := make(clockReport, len(clocks))
+	for i, e := range clocks {
+		r[i] = newClockRecord(e, now)
+	}
+	return emit(w, *f, r)
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultWeekend is the weekend assumed for entries that don't set one.
+const DefaultWeekend = "Sat,Sun"
+
+// Recognised Entry.Status values. Any other status is shown as a note and
+// does not change availability.
+const (
+	StatusOOO    = "ooo"
+	StatusOnCall = "on-call"
+)
+
+// Availability answers "can I ping them now" for an entry.
+type Availability string
+
+const (
+	Available   Availability = "AVAILABLE"
+	AfterHours  Availability = "AFTER HOURS"
+	Weekend     Availability = "WEEKEND"
+	OutOfOffice Availability = "OOO"
+	OnCall      Availability = "ON-CALL"
+)
+
+// Short is a compact form of the badge for tight layouts, at most 7 cells.
+func (a Availability) Short() string {
+	switch a {
+	case Available:
+		return "AVAIL"
+	case AfterHours:
+		return "AFTER"
+	case Weekend:
+		return "WKND"
+	default:
+		return string(a)
+	}
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWeekend parses a comma-separated list of weekday names ("Fri,Sat").
+// "none" means the entry works every day.
+func ParseWeekend(s string) ([]time.Weekday, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "none") {
+		return nil, nil
+	}
+	var out []time.Weekday
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		d, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("weekend: unknown day %q", part)
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// ParseUntil parses a status expiry date (YYYY-MM-DD).
+func ParseUntil(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("until: want YYYY-MM-DD, got %q", s)
+	}
+	return t, nil
+}
+
+// weekendOn reports whether d is a weekend day for the entry, falling back
+// to DefaultWeekend when unset or malformed.
+func (e Entry) weekendOn(d time.Weekday) bool {
+	days, err := ParseWeekend(e.Weekend)
+	if e.Weekend == "" || err != nil {
+		days, _ = ParseWeekend(DefaultWeekend)
+	}
+	for _, w := range days {
+		if w == d {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusAt returns the entry's status if it is in effect at t. A status with
+// an Until date lapses at local midnight starting that day.
+func (e Entry) StatusAt(t time.Time) string {
+	status := strings.TrimSpace(e.Status)
+	if status == "" || e.Until == "" {
+		return status
+	}
+	until, err := ParseUntil(e.Until)
+	if err != nil {
+		return status
+	}
+	local := e.At(t)
+	y, m, d := until.Date()
+	if !local.Before(time.Date(y, m, d, 0, 0, 0, 0, local.Location())) {
+		return ""
+	}
+	return status
+}
+
+// AvailabilityAt classifies the entry at instant t: a recognised status wins,
+// then weekends, then working hours.
+func (e Entry) AvailabilityAt(t time.Time) Availability {
+	switch strings.ToLower(e.StatusAt(t)) {
+	case StatusOOO:
+		return OutOfOffice
+	case StatusOnCall:
+		return OnCall
+	}
+	if e.weekendOn(e.At(t).Weekday()) {
+		return Weekend
+	}
+	if e.WorkingAt(t) {
+		return Available
+	}
+	return AfterHours
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekend(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []time.Weekday
+		err  bool
+	}{
+		{in: "Sat,Sun", want: []time.Weekday{time.Saturday, time.Sunday}},
+		{in: "fri, saturday", want: []time.Weekday{time.Friday, time.Saturday}},
+		{in: "none", want: nil},
+		{in: "NONE", want: nil},
+		{in: "Sat,Funday", err: true},
+		{in: "", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekend(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseWeekend(%q) error = %v, want error %v", tt.in, err, tt.err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseWeekend(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseWeekend(%q) = %v, want %v", tt.in, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestStatusAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		at    string // RFC 3339
+		want  string
+	}{
+		{"no status", Entry{Location: "UTC"}, "2026-07-15T10:00:00Z", ""},
+		{"open ended", Entry{Location: "UTC", Status: "ooo"}, "2026-07-15T10:00:00Z", "ooo"},
+		{"trimmed", Entry{Location: "UTC", Status: "  on-call "}, "2026-07-15T10:00:00Z", "on-call"},
+		{"before until", Entry{Location: "UTC", Status: "ooo", Until: "2026-07-16"}, "2026-07-15T23:59:00Z", "ooo"},
+		{"lapses at midnight", Entry{Location: "UTC", Status: "ooo", Until: "2026-07-16"}, "2026-07-16T00:00:00Z", ""},
+		{"after until", Entry{Location: "UTC", Status: "ooo", Until: "2026-07-16"}, "2026-07-20T10:00:00Z", ""},
+		// Midnight is the entry's own: 22:00 UTC on the 15th is already the
+		// 16th in Tokyo.
+		{"local midnight", Entry{Location: "Asia/Tokyo", Status: "ooo", Until: "2026-07-16"}, "2026-07-15T16:00:00Z", ""},
+		{"malformed until keeps status", Entry{Location: "UTC", Status: "ooo", Until: "next week"}, "2026-07-20T10:00:00Z", "ooo"},
+	}
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tt.entry.StatusAt(at); got != tt.want {
+			t.Errorf("%s: StatusAt(%s) = %q, want %q", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestAvailabilityAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		at    string // RFC 3339
+		want  Availability
+	}{
+		// 15 July 2026 is a Wednesday.
+		{"working", Entry{Location: "UTC"}, "2026-07-15T10:00:00Z", Available},
+		{"after hours", Entry{Location: "UTC"}, "2026-07-15T20:00:00Z", AfterHours},
+		{"weekend", Entry{Location: "UTC"}, "2026-07-18T10:00:00Z", Weekend},
+		{"no weekend", Entry{Location: "UTC", Weekend: "none"}, "2026-07-18T10:00:00Z", Available},
+		{"friday weekend", Entry{Location: "UTC", Weekend: "Fri,Sat"}, "2026-07-17T10:00:00Z", Weekend},
+		{"local weekday", Entry{Location: "Pacific/Auckland"}, "2026-07-17T20:00:00Z", Weekend},
+		{"ooo beats working", Entry{Location: "UTC", Status: "OOO"}, "2026-07-15T10:00:00Z", OutOfOffice},
+		{"on-call beats weekend", Entry{Location: "UTC", Status: "on-call"}, "2026-07-18T03:00:00Z", OnCall},
+		{"note is not a status", Entry{Location: "UTC", Status: "in Lisbon"}, "2026-07-15T10:00:00Z", Available},
+		{"lapsed ooo", Entry{Location: "UTC", Status: "ooo", Until: "2026-07-15"}, "2026-07-15T10:00:00Z", Available},
+		{"overnight shift", Entry{Location: "UTC", Hours: "22:00-06:00"}, "2026-07-16T03:00:00Z", Available},
+	}
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tt.entry.AvailabilityAt(at); got != tt.want {
+			t.Errorf("%s: AvailabilityAt(%s) = %s, want %s", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
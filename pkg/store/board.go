@@ -0,0 +1,70 @@
+package store
+
+import "slices"
+
+// DefaultBoard is the board a fresh dashboard starts with, and the one older
+// configs' clocks are moved into.
+const DefaultBoard = "default"
+
+// Board is a named dashboard ("team", "customers", "travel").
+type Board struct {
+	Name   string  `json:"name"`
+	Clocks []Entry `json:"clocks"`
+}
+
+// Equal reports whether b and o have the same name and clocks.
+func (b Board) Equal(o Board) bool {
+	return b.Name == o.Name && slices.Equal(b.Clocks, o.Clocks)
+}
+
+// boardOverride is set from the --board flag and wins over Config.Active.
+var boardOverride string
+
+// SetBoard pins the board for this process (the --board flag).
+func SetBoard(name string) {
+	boardOverride = name
+}
+
+// Selected names the board in effect: the --board flag (SetBoard), else
+// Active, else the first board. The named board need not exist yet.
+func (c Config) Selected() string {
+	if boardOverride != "" {
+		return boardOverride
+	}
+	if c.Active != "" && c.Board(c.Active) != nil {
+		return c.Active
+	}
+	if len(c.Boards) > 0 {
+		return c.Boards[0].Name
+	}
+	return DefaultBoard
+}
+
+// Board returns the named board, or nil if there is none.
+func (c *Config) Board(name string) *Board {
+	for i := range c.Boards {
+		if c.Boards[i].Name == name {
+			return &c.Boards[i]
+		}
+	}
+	return nil
+}
+
+// AddBoard appends an empty board and returns it. Callers check for an
+// existing board of the same name first.
+func (c *Config) AddBoard(name string) *Board {
+	c.Boards = append(c.Boards, Board{Name: name})
+	return &c.Boards[len(c.Boards)-1]
+}
+
+// WithBoard returns a copy of c with the named board's clocks replaced,
+// adding the board if it doesn't exist. c itself is left untouched.
+func (c Config) WithBoard(name string, clocks []Entry) Config {
+	c.Boards = slices.Clone(c.Boards)
+	b := c.Board(name)
+	if b == nil {
+		b = c.AddBoard(name)
+	}
+	b.Clocks = slices.Clone(clocks)
+	return c
+}
@@ -0,0 +1,65 @@
+package store
+
+// Problem is a clock field that doesn't parse. Such a config still loads —
+// the entry falls back to local time or the defaults — so Check is what
+// finds them.
+type Problem struct {
+	Board string
+	Index int // position of the clock on its board, from 0
+	Entry Entry
+	Field string // JSON name of the field: "location", "hours", ...
+	Value string
+	Err   error
+	// Suggestion is a likely intended value for an unknown location, or "".
+	Suggestion string
+}
+
+// String reads like the parser's error, plus the suggestion if any:
+// `unknown zone "Europe/Istambul" — did you mean Europe/Istanbul?`.
+func (p Problem) String() string {
+	s := p.Err.Error()
+	if p.Suggestion != "" {
+		s += " — did you mean " + p.Suggestion + "?"
+	}
+	return s
+}
+
+// Check validates every clock on every board: its location against the
+// runtime's zoneinfo, and its hours, weekend and until fields against their
+// parsers. Problems are returned in board and clock order.
+func (c Config) Check() []Problem {
+	var out []Problem
+	for _, b := range c.Boards {
+		for i, e := range b.Clocks {
+			out = append(out, e.check(b.Name, i)...)
+		}
+	}
+	return out
+}
+
+func (e Entry) check(board string, index int) []Problem {
+	var out []Problem
+	add := func(field, value string, err error) {
+		out = append(out, Problem{Board: board, Index: index, Entry: e, Field: field, Value: value, Err: err})
+	}
+	if _, err := e.Zone(); err != nil {
+		add("location", e.Location, err)
+		out[len(out)-1].Suggestion = SuggestZone(e.Location)
+	}
+	if e.Hours != "" {
+		if _, err := ParseHours(e.Hours); err != nil {
+			add("hours", e.Hours, err)
+		}
+	}
+	if e.Weekend != "" {
+		if _, err := ParseWeekend(e.Weekend); err != nil {
+			add("weekend", e.Weekend, err)
+		}
+	}
+	if e.Until != "" {
+		if _, err := ParseUntil(e.Until); err != nil {
+			add("until", e.Until, err)
+		}
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	cfg := Config{Boards: []Board{
+		{Name: "team", Clocks: []Entry{
+			{Label: "Local", Location: "Local"},
+			{Label: "Istanbul", Location: "Europe/Istambul", Hours: "9-5"},
+		}},
+		{Name: "travel", Clocks: []Entry{
+			{Label: "Tokyo", Location: "Asia/Tokyo", Weekend: "Sat,Sun", Until: "soon"},
+		}},
+	}}
+	want := []string{
+		`team #1 location: unknown zone "Europe/Istambul" — did you mean Europe/Istanbul?`,
+		`team #1 hours: working hours "9-5": bad time "9"`,
+		`travel #0 until: until: want YYYY-MM-DD, got "soon"`,
+	}
+	got := cfg.Check()
+	if len(got) != len(want) {
+		t.Fatalf("Check() found %d problems, want %d: %v", len(got), len(want), got)
+	}
+	for i, p := range got {
+		if s := fmt.Sprintf("%s #%d %s: %s", p.Board, p.Index, p.Field, p); s != want[i] {
+			t.Errorf("problem %d = %s, want %s", i, s, want[i])
+		}
+	}
+}
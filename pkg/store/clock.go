@@ -0,0 +1,20 @@
+package store
+
+import "time"
+
+// Clock is where the current time comes from. The UI reads it instead of
+// calling time.Now, so tests and replays can pin it; entries are then
+// placed on it with Entry.At.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// FixedClock always reports the same instant.
+type FixedClock time.Time
+
+func (c FixedClock) Now() time.Time { return time.Time(c) }
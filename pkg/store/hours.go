@@ -0,0 +1,98 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultHours is the working window assumed for entries that don't set one.
+const DefaultHours = "09:00-17:00"
+
+// Window is a daily working window in minutes after local midnight. End may
+// be less than Start for windows that cross midnight ("22:00-06:00"), and is
+// 1440 for windows that run to midnight ("18:00-24:00").
+type Window struct {
+	Start, End int
+}
+
+// Contains reports whether the minute-of-day falls inside the window.
+func (w Window) Contains(minute int) bool {
+	if w.Start <= w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	return minute >= w.Start || minute < w.End
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+// ParseHours parses a comma-separated list of "HH:MM-HH:MM" windows, e.g.
+// "09:00-12:00,13:00-18:00".
+func ParseHours(s string) ([]Window, error) {
+	var out []Window
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		from, to, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, fmt.Errorf("working hours %q: want HH:MM-HH:MM", part)
+		}
+		start, err := parseMinute(from)
+		if err != nil {
+			return nil, fmt.Errorf("working hours %q: %w", part, err)
+		}
+		if start == 24*60 {
+			return nil, fmt.Errorf("working hours %q: 24:00 can only end a window", part)
+		}
+		end, err := parseMinute(to)
+		if err != nil {
+			return nil, fmt.Errorf("working hours %q: %w", part, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("working hours %q: empty window", part)
+		}
+		out = append(out, Window{Start: start, End: end})
+	}
+	return out, nil
+}
+
+func parseMinute(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		// "24:00" is a natural way to write end-of-day.
+		if strings.TrimSpace(s) == "24:00" {
+			return 24 * 60, nil
+		}
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// WorkingHours returns the entry's working windows, falling back to
+// DefaultHours when unset or malformed.
+func (e Entry) WorkingHours() []Window {
+	if e.Hours != "" {
+		if ws, err := ParseHours(e.Hours); err == nil {
+			return ws
+		}
+	}
+	ws, _ := ParseHours(DefaultHours)
+	return ws
+}
+
+// WorkingAt reports whether the instant t falls inside the entry's working
+// hours on a working day, on the entry's local wall clock.
+func (e Entry) WorkingAt(t time.Time) bool {
+	local := e.At(t)
+	if e.weekendOn(local.Weekday()) {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range e.WorkingHours() {
+		if w.Contains(minute) {
+			return true
+		}
+	}
+	return false
+}
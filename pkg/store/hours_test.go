@@ -0,0 +1,112 @@
+package store
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Window // nil for an error
+	}{
+		{"09:00-17:00", []Window{{540, 1020}}},
+		{"09:00-12:00, 13:00-18:00", []Window{{540, 720}, {780, 1080}}},
+		{"22:00-06:00", []Window{{1320, 360}}},
+		{"18:00-24:00", []Window{{1080, 1440}}},
+		{"00:00-24:00", []Window{{0, 1440}}},
+		{"24:00-06:00", nil},
+		{"9-5", nil},
+		{"09:00", nil},
+		{"09:00-09:00", nil},
+		{"09:00-25:00", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseHours(tt.in)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("ParseHours(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseHours(%q): %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseHours(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseHours(%q) = %v, want %v", tt.in, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	day := Window{Start: 9 * 60, End: 17 * 60}
+	night := Window{Start: 22 * 60, End: 6 * 60}
+	tests := []struct {
+		w      Window
+		minute int
+		want   bool
+	}{
+		{day, 9 * 60, true},
+		{day, 17*60 - 1, true},
+		{day, 17 * 60, false},
+		{day, 8*60 + 59, false},
+		{night, 22 * 60, true},
+		{night, 23*60 + 59, true},
+		{night, 0, true},
+		{night, 6*60 - 1, true},
+		{night, 6 * 60, false},
+		{night, 12 * 60, false},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Contains(tt.minute); got != tt.want {
+			t.Errorf("%v.Contains(%02d:%02d) = %v, want %v", tt.w, tt.minute/60, tt.minute%60, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		at    string // RFC 3339
+		want  bool
+	}{
+		// 15 July 2026 is a Wednesday.
+		{"default hours", Entry{Location: "UTC"}, "2026-07-15T10:00:00Z", true},
+		{"before default hours", Entry{Location: "UTC"}, "2026-07-15T08:59:00Z", false},
+		{"end is exclusive", Entry{Location: "UTC"}, "2026-07-15T17:00:00Z", false},
+		{"local wall clock", Entry{Location: "Asia/Tokyo"}, "2026-07-15T01:00:00Z", true},
+		{"split day lunch", Entry{Location: "UTC", Hours: "09:00-12:00,13:00-18:00"}, "2026-07-15T12:30:00Z", false},
+		{"split day afternoon", Entry{Location: "UTC", Hours: "09:00-12:00,13:00-18:00"}, "2026-07-15T17:30:00Z", true},
+		{"overnight late", Entry{Location: "UTC", Hours: "22:00-06:00"}, "2026-07-15T23:00:00Z", true},
+		{"overnight early", Entry{Location: "UTC", Hours: "22:00-06:00"}, "2026-07-16T05:00:00Z", true},
+		{"overnight midday", Entry{Location: "UTC", Hours: "22:00-06:00"}, "2026-07-15T12:00:00Z", false},
+		// The weekday is the one on the local wall clock at that moment.
+		{"overnight into saturday", Entry{Location: "UTC", Hours: "22:00-06:00"}, "2026-07-18T02:00:00Z", false},
+		{"saturday", Entry{Location: "UTC"}, "2026-07-18T10:00:00Z", false},
+		{"custom weekend", Entry{Location: "UTC", Weekend: "Fri,Sat"}, "2026-07-19T10:00:00Z", true},
+		{"all day", Entry{Location: "UTC", Hours: "00:00-24:00"}, "2026-07-15T23:59:00Z", true},
+		{"to midnight", Entry{Location: "UTC", Hours: "18:00-24:00"}, "2026-07-15T23:30:00Z", true},
+		{"to midnight, not past it", Entry{Location: "UTC", Hours: "18:00-24:00"}, "2026-07-16T00:00:00Z", false},
+		{"malformed hours fall back", Entry{Location: "UTC", Hours: "nine to five"}, "2026-07-15T10:00:00Z", true},
+	}
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tt.entry.WorkingAt(at); got != tt.want {
+			t.Errorf("%s: WorkingAt(%s) = %v, want %v", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package store
+
+import "slices"
+
+// clockKey identifies an entry across versions of a clock list. Identical
+// entries are told apart by their occurrence index.
+type clockKey struct {
+	Entry
+	n int
+}
+
+func clockKeys(clocks []Entry) []clockKey {
+	seen := map[Entry]int{}
+	keys := make([]clockKey, len(clocks))
+	for i, e := range clocks {
+		keys[i] = clockKey{e, seen[e]}
+		seen[e]++
+	}
+	return keys
+}
+
+func keySet(keys []clockKey) map[clockKey]bool {
+	set := make(map[clockKey]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// reordered reports whether the entries side kept from base appear in a
+// different relative order.
+func reordered(base, side []clockKey) bool {
+	inSide := keySet(side)
+	inBase := keySet(base)
+	var a, b []clockKey
+	for _, k := range base {
+		if inSide[k] {
+			a = append(a, k)
+		}
+	}
+	for _, k := range side {
+		if inBase[k] {
+			b = append(b, k)
+		}
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// additionsAt groups the entries side added to base by the base position of
+// the entry they follow — the nearest preceding one side kept — with -1 for
+// the front.
+func additionsAt(base, side []clockKey) map[int][]clockKey {
+	pos := make(map[clockKey]int, len(base))
+	for i, k := range base {
+		pos[k] = i
+	}
+	out := map[int][]clockKey{}
+	at := -1
+	for _, k := range side {
+		if i, ok := pos[k]; ok {
+			at = i
+			continue
+		}
+		out[at] = append(out[at], k)
+	}
+	return out
+}
+
+// removedAt maps each base entry side removed to the base position of the
+// nearest preceding entry side kept, or -1: the spot where additionsAt files
+// whatever side put in its place.
+func removedAt(base []clockKey, inSide map[clockKey]bool) map[clockKey]int {
+	out := map[clockKey]int{}
+	at := -1
+	for i, k := range base {
+		if inSide[k] {
+			at = i
+			continue
+		}
+		out[k] = at
+	}
+	return out
+}
+
+// editedApart reports whether both sides replaced the same base entry, each
+// with something the other doesn't have: an entry removed by both, with
+// additions from both in its spot. That is how two different edits of one
+// clock look.
+func editedApart(base, ours, theirs []clockKey) bool {
+	inOurs, inTheirs := keySet(ours), keySet(theirs)
+	oursAdded, theirsAdded := additionsAt(base, ours), additionsAt(base, theirs)
+	theirsGone := removedAt(base, inTheirs)
+	only := func(added []clockKey, other map[clockKey]bool) bool {
+		return slices.ContainsFunc(added, func(k clockKey) bool { return !other[k] })
+	}
+	for k, at := range removedAt(base, inOurs) {
+		if tat, ok := theirsGone[k]; ok && only(oursAdded[at], inTheirs) && only(theirsAdded[tat], inOurs) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeClocks merges two concurrent edits (ours, theirs) of base. Additions
+// and removals from both sides are combined; an edited entry counts as a
+// removal plus an addition in the same spot. The order of whichever side
+// reordered is kept. It reports false when both sides reordered, since
+// there's no faithful way to combine two rearrangements, and when both
+// edited the same entry differently.
+func mergeClocks(base, ours, theirs []Entry) ([]Entry, bool) {
+	switch {
+	case slices.Equal(theirs, base), slices.Equal(ours, theirs):
+		return ours, true
+	case slices.Equal(ours, base):
+		return theirs, true
+	}
+
+	bk, ok, tk := clockKeys(base), clockKeys(ours), clockKeys(theirs)
+	oursMoved, theirsMoved := reordered(bk, ok), reordered(bk, tk)
+	if oursMoved && theirsMoved || editedApart(bk, ok, tk) {
+		return nil, false
+	}
+
+	// primary's order wins; secondary's additions and removals are replayed
+	// on top of it.
+	primary, secondary := ok, tk
+	if theirsMoved {
+		primary, secondary = tk, ok
+	}
+	inBase, inSecondary := keySet(bk), keySet(secondary)
+
+	var result []clockKey
+	for _, k := range primary {
+		if inBase[k] && !inSecondary[k] {
+			continue // removed by secondary
+		}
+		result = append(result, k)
+	}
+
+	primaryAdded, primaryGone := additionsAt(bk, primary), removedAt(bk, keySet(primary))
+	for i, k := range secondary {
+		if inBase[k] || slices.Contains(result, k) {
+			continue
+		}
+		// Insert after the nearest preceding entry secondary had that
+		// survived the merge — or, for one primary replaced, after its
+		// replacement — or at the front.
+		at := 0
+		for j := i - 1; j >= 0 && at == 0; j-- {
+			if r := slices.Index(result, secondary[j]); r >= 0 {
+				at = r + 1
+			} else if spot, gone := primaryGone[secondary[j]]; gone {
+				if adds := primaryAdded[spot]; len(adds) > 0 {
+					at = slices.Index(result, adds[len(adds)-1]) + 1
+				}
+			}
+		}
+		result = slices.Insert(result, at, k)
+	}
+
+	out := make([]Entry, len(result))
+	for i, k := range result {
+		out[i] = k.Entry
+	}
+	return out, true
+}
+
+func findBoard(boards []Board, name string) (Board, bool) {
+	for _, b := range boards {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return Board{}, false
+}
+
+// mergeBoards merges two concurrent edits of base's boards, clock list by
+// clock list with mergeClocks. A board one side removed is dropped unless
+// the other side changed it; boards added by theirs go after ours. It
+// reports false when any board can't be merged.
+func mergeBoards(base, ours, theirs []Board) ([]Board, bool) {
+	var out []Board
+	for _, o := range ours {
+		b, inBase := findBoard(base, o.Name)
+		t, inTheirs := findBoard(theirs, o.Name)
+		if !inTheirs {
+			if inBase && o.Equal(b) {
+				continue // removed by theirs
+			}
+			out = append(out, o)
+			continue
+		}
+		clocks, ok := mergeClocks(b.Clocks, o.Clocks, t.Clocks)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, Board{Name: o.Name, Clocks: clocks})
+	}
+	for _, t := range theirs {
+		if _, inOurs := findBoard(ours, t.Name); inOurs {
+			continue
+		}
+		if b, inBase := findBoard(base, t.Name); inBase && t.Equal(b) {
+			continue // removed by ours
+		}
+		out = append(out, t)
+	}
+	return out, true
+}
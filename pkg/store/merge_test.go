@@ -0,0 +1,229 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// clocks builds a clock list from labels; "X=zone" sets the location, which
+// is how the cases express an edit to X.
+func clocks(labels ...string) []Entry {
+	out := make([]Entry, len(labels))
+	for i, l := range labels {
+		label, zone, ok := strings.Cut(l, "=")
+		if !ok {
+			zone = "UTC"
+		}
+		out[i] = Entry{Label: label, Location: zone}
+	}
+	return out
+}
+
+func labels(clocks []Entry) []string {
+	out := make([]string, len(clocks))
+	for i, e := range clocks {
+		out[i] = e.Label
+		if e.Location != "UTC" {
+			out[i] += "=" + e.Location
+		}
+	}
+	return out
+}
+
+func TestMergeClocks(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs []Entry
+		want               []Entry // nil when the merge must fail
+	}{
+		{
+			name: "only ours changed",
+			base: clocks("A", "B"), ours: clocks("A", "B", "C"), theirs: clocks("A", "B"),
+			want: clocks("A", "B", "C"),
+		},
+		{
+			name: "only theirs changed",
+			base: clocks("A", "B"), ours: clocks("A", "B"), theirs: clocks("B"),
+			want: clocks("B"),
+		},
+		{
+			// Theirs' addition goes right after the clock it followed.
+			name: "both add at the end",
+			base: clocks("A", "B"), ours: clocks("A", "B", "C"), theirs: clocks("A", "B", "D"),
+			want: clocks("A", "B", "D", "C"),
+		},
+		{
+			name: "both add in different spots",
+			base: clocks("A", "B"), ours: clocks("C", "A", "B"), theirs: clocks("A", "D", "B"),
+			want: clocks("C", "A", "D", "B"),
+		},
+		{
+			name: "both add the same clock",
+			base: clocks("A"), ours: clocks("A", "C"), theirs: clocks("A", "C"),
+			want: clocks("A", "C"),
+		},
+		{
+			name: "both remove different clocks",
+			base: clocks("A", "B", "C"), ours: clocks("A", "C"), theirs: clocks("A", "B"),
+			want: clocks("A"),
+		},
+		{
+			name: "ours removes while theirs edits",
+			base: clocks("A", "B", "C"), ours: clocks("A", "C"), theirs: clocks("A", "B=Asia/Tokyo", "C"),
+			want: clocks("A", "B=Asia/Tokyo", "C"),
+		},
+		{
+			name: "theirs removes while ours edits",
+			base: clocks("A", "B", "C"), ours: clocks("A", "B=Asia/Tokyo", "C"), theirs: clocks("A", "C"),
+			want: clocks("A", "B=Asia/Tokyo", "C"),
+		},
+		{
+			name: "both edit the same clock alike",
+			base: clocks("A", "B"), ours: clocks("A", "B=Asia/Tokyo", "C"), theirs: clocks("A", "B=Asia/Tokyo"),
+			want: clocks("A", "B=Asia/Tokyo", "C"),
+		},
+		{
+			name: "both edit the same clock differently",
+			base: clocks("A", "B"), ours: clocks("A", "B=Asia/Tokyo"), theirs: clocks("A", "B=Europe/Paris"),
+			want: nil,
+		},
+		{
+			name: "both rename the same clock",
+			base: clocks("A", "B"), ours: clocks("A", "C"), theirs: clocks("A", "D"),
+			want: nil,
+		},
+		{
+			name: "both remove a clock, one adds elsewhere",
+			base: clocks("A", "B", "C"), ours: clocks("A", "C", "D"), theirs: clocks("A", "C"),
+			want: clocks("A", "C", "D"),
+		},
+		{
+			name: "both edit different clocks",
+			base: clocks("A", "B"), ours: clocks("A=Asia/Tokyo", "B"), theirs: clocks("A", "B=Europe/Paris"),
+			want: clocks("A=Asia/Tokyo", "B=Europe/Paris"),
+		},
+		{
+			name: "ours reorders while theirs adds",
+			base: clocks("A", "B", "C"), ours: clocks("C", "A", "B"), theirs: clocks("A", "B", "D", "C"),
+			want: clocks("C", "A", "B", "D"),
+		},
+		{
+			name: "theirs reorders while ours removes",
+			base: clocks("A", "B", "C"), ours: clocks("A", "C"), theirs: clocks("C", "B", "A"),
+			want: clocks("C", "A"),
+		},
+		{
+			name: "both reorder the same way",
+			base: clocks("A", "B", "C"), ours: clocks("C", "B", "A"), theirs: clocks("C", "B", "A"),
+			want: clocks("C", "B", "A"),
+		},
+		{
+			name: "both reorder differently",
+			base: clocks("A", "B", "C"), ours: clocks("C", "A", "B"), theirs: clocks("B", "A", "C"),
+			want: nil,
+		},
+		{
+			name: "duplicates are told apart",
+			base: clocks("A", "A"), ours: clocks("A"), theirs: clocks("A", "A", "B"),
+			want: clocks("A", "B"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mergeClocks(tt.base, tt.ours, tt.theirs)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("mergeClocks = %v, want a conflict", labels(got))
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("mergeClocks reported a conflict, want %v", labels(tt.want))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("mergeClocks = %v, want %v", labels(got), labels(tt.want))
+			}
+		})
+	}
+}
+
+func TestMergeBoards(t *testing.T) {
+	board := func(name string, labels ...string) Board {
+		return Board{Name: name, Clocks: clocks(labels...)}
+	}
+	tests := []struct {
+		name               string
+		base, ours, theirs []Board
+		want               []Board // nil when the merge must fail
+	}{
+		{
+			name:   "clocks merged board by board",
+			base:   []Board{board("team", "A"), board("travel", "T")},
+			ours:   []Board{board("team", "A", "B"), board("travel", "T")},
+			theirs: []Board{board("team", "A"), board("travel", "T", "U")},
+			want:   []Board{board("team", "A", "B"), board("travel", "T", "U")},
+		},
+		{
+			name:   "both add boards",
+			base:   []Board{board("team", "A")},
+			ours:   []Board{board("team", "A"), board("travel", "T")},
+			theirs: []Board{board("team", "A"), board("customers", "C")},
+			want:   []Board{board("team", "A"), board("travel", "T"), board("customers", "C")},
+		},
+		{
+			name:   "ours renames a board",
+			base:   []Board{board("team", "A"), board("travel", "T")},
+			ours:   []Board{board("crew", "A"), board("travel", "T")},
+			theirs: []Board{board("team", "A"), board("travel", "T", "U")},
+			want:   []Board{board("crew", "A"), board("travel", "T", "U")},
+		},
+		{
+			name:   "theirs renames a board",
+			base:   []Board{board("team", "A"), board("travel", "T")},
+			ours:   []Board{board("team", "A"), board("travel", "T", "U")},
+			theirs: []Board{board("crew", "A"), board("travel", "T")},
+			want:   []Board{board("travel", "T", "U"), board("crew", "A")},
+		},
+		{
+			// The rename can't be told from a removal plus an addition, so
+			// the edit keeps the old name rather than being lost.
+			name:   "rename while the other side edits the board",
+			base:   []Board{board("team", "A")},
+			ours:   []Board{board("crew", "A")},
+			theirs: []Board{board("team", "A", "B")},
+			want:   []Board{board("crew", "A"), board("team", "A", "B")},
+		},
+		{
+			name:   "removed board the other side edited survives",
+			base:   []Board{board("team", "A"), board("travel", "T")},
+			ours:   []Board{board("team", "A")},
+			theirs: []Board{board("team", "A"), board("travel", "T", "U")},
+			want:   []Board{board("team", "A"), board("travel", "T", "U")},
+		},
+		{
+			name:   "conflicting reorders fail the whole merge",
+			base:   []Board{board("team", "A", "B", "C")},
+			ours:   []Board{board("team", "C", "A", "B")},
+			theirs: []Board{board("team", "B", "A", "C")},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mergeBoards(tt.base, tt.ours, tt.theirs)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("mergeBoards = %v, want a conflict", got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("mergeBoards reported a conflict, want %v", tt.want)
+			}
+			if !slices.EqualFunc(got, tt.want, Board.Equal) {
+				t.Errorf("mergeBoards = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
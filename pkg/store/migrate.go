@@ -0,0 +1,112 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CurrentVersion is the config schema version this build reads and writes.
+const CurrentVersion = 2
+
+// ErrTooNew is wrapped by Load when the config was written by a newer
+// release; it is never rewritten, so the newer release doesn't lose data.
+var ErrTooNew = errors.New("config was written by a newer atlas.clock")
+
+// migration upgrades a raw config document from version from to from+1.
+type migration struct {
+	from  int
+	desc  string
+	apply func(doc map[string]any) error
+}
+
+// migrations must stay ordered and contiguous: each step's from is the
+// previous step's from+1.
+var migrations = []migration{
+	{0, `add "version"; blank locations become "Local"`, migrateV0},
+	{1, `move "clocks" into the "` + DefaultBoard + `" board`, migrateV1},
+}
+
+// migrateV0 upgrades the original, unversioned format. Entries written by
+// hand sometimes left "location" empty, which always meant local time.
+func migrateV0(doc map[string]any) error {
+	clocks, _ := doc["clocks"].([]any)
+	for _, c := range clocks {
+		entry, ok := c.(map[string]any)
+		if !ok {
+			return errors.New("clocks: entry is not an object")
+		}
+		if loc, _ := entry["location"].(string); strings.TrimSpace(loc) == "" {
+			entry["location"] = "Local"
+		}
+	}
+	return nil
+}
+
+// migrateV1 moves the single clock list into a board of its own, so a
+// config can hold several named dashboards.
+func migrateV1(doc map[string]any) error {
+	clocks, ok := doc["clocks"].([]any)
+	if !ok && doc["clocks"] != nil {
+		return errors.New("clocks: not a list")
+	}
+	if clocks == nil {
+		clocks = []any{}
+	}
+	delete(doc, "clocks")
+	doc["boards"] = []any{map[string]any{"name": DefaultBoard, "clocks": clocks}}
+	doc["active"] = DefaultBoard
+	return nil
+}
+
+// docVersion reads the "version" key; documents without one are version 0.
+func docVersion(doc map[string]any) (int, error) {
+	v, ok := doc["version"]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := v.(float64)
+	if !ok || n != float64(int(n)) || n < 0 {
+		return 0, fmt.Errorf("version: want a non-negative integer, got %v", v)
+	}
+	return int(n), nil
+}
+
+// Migrate upgrades a raw config document to CurrentVersion, one step at a
+// time, and returns the upgraded document with a description of each step
+// applied. A document already at CurrentVersion is returned unchanged.
+func Migrate(data []byte) ([]byte, []string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, errors.New("config is not a JSON object")
+	}
+	version, err := docVersion(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version > CurrentVersion {
+		return nil, nil, fmt.Errorf("%w (version %d, this build reads up to %d)", ErrTooNew, version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return data, nil, nil
+	}
+
+	var steps []string
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		if err := m.apply(doc); err != nil {
+			return nil, steps, fmt.Errorf("migrate v%d → v%d: %w", m.from, m.from+1, err)
+		}
+		version = m.from + 1
+		doc["version"] = version
+		steps = append(steps, fmt.Sprintf("v%d → v%d: %s", m.from, version, m.desc))
+	}
+	out, err := json.Marshal(doc)
+	return out, steps, err
+}
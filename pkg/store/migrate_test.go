@@ -0,0 +1,117 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestMigrate(t *testing.T) {
+	homes := []Entry{{Label: "Home", Location: "Local"}, {Label: "Also home", Location: "Local"}}
+	rest := []Entry{
+		{Label: "Berlin", Location: "Europe/Berlin", Hours: "09:00-17:00"},
+		{Label: "Tokyo", Location: "Asia/Tokyo"},
+	}
+	tests := []struct {
+		fixture string
+		steps   int
+		clocks  []Entry
+	}{
+		{"v0.json", 2, append(slices.Clone(homes), rest...)},
+		{"v1.json", 1, append(homes[:1:1], rest...)},
+		{"v2.json", 0, append(homes[:1:1], rest...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			data, steps, err := Migrate(readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(steps) != tt.steps {
+				t.Errorf("steps = %q, want %d", steps, tt.steps)
+			}
+			var cfg Config
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				t.Fatal(err)
+			}
+			want := Config{Version: CurrentVersion, Active: DefaultBoard, Boards: []Board{
+				{Name: DefaultBoard, Clocks: tt.clocks},
+			}}
+			if !reflect.DeepEqual(cfg, want) {
+				t.Errorf("migrated config = %+v, want %+v", cfg, want)
+			}
+		})
+	}
+}
+
+func TestMigrateCurrentIsUnchanged(t *testing.T) {
+	in := readFixture(t, "v2.json")
+	out, steps, err := Migrate(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 0 || string(out) != string(in) {
+		t.Errorf("Migrate rewrote a current document: steps %q\n%s", steps, out)
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		tooNew bool
+	}{
+		{name: "newer version", in: `{"version": 3, "boards": []}`, tooNew: true},
+		{name: "much newer version", in: `{"version": 40}`, tooNew: true},
+		{name: "not an object", in: `[1, 2]`},
+		{name: "null", in: `null`},
+		{name: "bad version", in: `{"version": "two"}`},
+		{name: "fractional version", in: `{"version": 1.5}`},
+		{name: "negative version", in: `{"version": -1}`},
+		{name: "v0 entry not an object", in: `{"clocks": ["Berlin"]}`},
+		{name: "v1 clocks not a list", in: `{"version": 1, "clocks": {"label": "Berlin"}}`},
+		{name: "not json", in: `{"clocks": [`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Migrate([]byte(tt.in))
+			if err == nil {
+				t.Fatal("Migrate succeeded, want an error")
+			}
+			if errors.Is(err, ErrTooNew) != tt.tooNew {
+				t.Errorf("Migrate error = %v; ErrTooNew %v, want %v", err, errors.Is(err, ErrTooNew), tt.tooNew)
+			}
+		})
+	}
+}
+
+func TestLoadTooNewIsLeftAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clock.json")
+	in := []byte(`{"version": 3, "boards": [{"name": "future", "clocks": []}]}`)
+	if err := os.WriteFile(path, in, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := load(path)
+	if !errors.Is(err, ErrTooNew) {
+		t.Fatalf("load = %v, want ErrTooNew", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("load returned %+v, want the default dashboard", cfg)
+	}
+	if m, _ := filepath.Glob(path + ".*"); len(m) != 0 {
+		t.Errorf("a newer config was copied aside: %v", m)
+	}
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// EnvConfig is the environment variable that overrides the config path.
+const EnvConfig = "ATLAS_CLOCK_CONFIG"
+
+// Source says which rule picked the config path.
+type Source string
+
+const (
+	SourceFlag Source = "--config flag"
+	SourceEnv  Source = "$" + EnvConfig
+	SourceXDG  Source = "XDG config directory"
+	SourceHome Source = "home directory"
+)
+
+// Location is the resolved config file and why it was chosen. System is the
+// read-only, system-wide default dashboard; it seeds Load while Path doesn't
+// exist yet and is never written.
+type Location struct {
+	Path   string `json:"path"`
+	Source Source `json:"source"`
+	System string `json:"system"`
+}
+
+// override is set from the --config flag and wins over everything else.
+var override string
+
+// SetConfigPath pins the config path for this process (the --config flag).
+func SetConfigPath(path string) {
+	override = path
+}
+
+// Resolve picks the config file, in order of precedence:
+//
+//  1. the --config flag (SetConfigPath)
+//  2. $ATLAS_CLOCK_CONFIG
+//  3. on Linux, $XDG_CONFIG_HOME/atlas/clock.json (~/.config when unset) if
+//     that file exists, or if XDG_CONFIG_HOME is set and ~/.atlas/clock.json
+//     doesn't exist
+//  4. ~/.atlas/clock.json
+func Resolve() (Location, error) {
+	loc := Location{System: systemConfigPath()}
+	if override != "" {
+		loc.Path, loc.Source = override, SourceFlag
+		return loc, nil
+	}
+	if env := os.Getenv(EnvConfig); env != "" {
+		loc.Path, loc.Source = env, SourceEnv
+		return loc, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return loc, fmt.Errorf("locate config: %w (set %s or pass --config)", err, EnvConfig)
+	}
+	legacy := filepath.Join(home, ".atlas", "clock.json")
+
+	if runtime.GOOS == "linux" {
+		xdgHome := os.Getenv("XDG_CONFIG_HOME")
+		dir := xdgHome
+		if dir == "" {
+			dir = filepath.Join(home, ".config")
+		}
+		xdg := filepath.Join(dir, "atlas", "clock.json")
+		if exists(xdg) || (xdgHome != "" && !exists(legacy)) {
+			loc.Path, loc.Source = xdg, SourceXDG
+			return loc, nil
+		}
+	}
+	loc.Path, loc.Source = legacy, SourceHome
+	return loc, nil
+}
+
+// ConfigPath returns the config file in effect; see Resolve.
+func ConfigPath() (string, error) {
+	loc, err := Resolve()
+	return loc.Path, err
+}
+
+// systemConfigPath is the read-only, system-wide default dashboard. It's a
+// variable so tests can seed from a file of their own.
+var systemConfigPath = func() string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("ProgramData"); dir != "" {
+			return filepath.Join(dir, "atlas", "clock.json")
+		}
+		return ""
+	}
+	return "/etc/atlas/clock.json"
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
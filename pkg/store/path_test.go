@@ -0,0 +1,160 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// touch creates an empty file at path, with its directories.
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		flag, env string
+		xdgHome   bool     // set XDG_CONFIG_HOME to <tmp>/xdg
+		files     []string // created first, relative to the temp dir
+		linux     bool     // the XDG rules only apply on Linux
+		want      string   // relative to the temp dir
+		source    Source
+	}{
+		{
+			name: "flag beats everything", flag: "flag.json", env: "env.json",
+			files: []string{"home/.atlas/clock.json", "home/.config/atlas/clock.json"},
+			want:  "flag.json", source: SourceFlag,
+		},
+		{
+			name: "env beats XDG and home", env: "env.json", xdgHome: true,
+			files: []string{"home/.atlas/clock.json", "xdg/atlas/clock.json"},
+			want:  "env.json", source: SourceEnv,
+		},
+		{
+			name: "existing XDG file beats home", linux: true,
+			files: []string{"home/.atlas/clock.json", "home/.config/atlas/clock.json"},
+			want:  "home/.config/atlas/clock.json", source: SourceXDG,
+		},
+		{
+			name: "XDG_CONFIG_HOME set and nothing yet", xdgHome: true, linux: true,
+			want: "xdg/atlas/clock.json", source: SourceXDG,
+		},
+		{
+			name: "XDG_CONFIG_HOME set but home file exists", xdgHome: true,
+			files: []string{"home/.atlas/clock.json"},
+			want:  "home/.atlas/clock.json", source: SourceHome,
+		},
+		{
+			name: "nothing set, nothing yet",
+			want: "home/.atlas/clock.json", source: SourceHome,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.linux && runtime.GOOS != "linux" {
+				t.Skip("XDG paths are Linux-only")
+			}
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				touch(t, filepath.Join(dir, f))
+			}
+			t.Setenv("HOME", filepath.Join(dir, "home"))
+			t.Setenv("USERPROFILE", filepath.Join(dir, "home"))
+			t.Setenv("XDG_CONFIG_HOME", "")
+			if tt.xdgHome {
+				t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+			}
+			t.Setenv(EnvConfig, "")
+			if tt.env != "" {
+				t.Setenv(EnvConfig, filepath.Join(dir, tt.env))
+			}
+			if tt.flag != "" {
+				SetConfigPath(filepath.Join(dir, tt.flag))
+				t.Cleanup(func() { SetConfigPath("") })
+			}
+
+			loc, err := Resolve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := filepath.Join(dir, tt.want); loc.Path != want || loc.Source != tt.source {
+				t.Errorf("Resolve() = %s (%s), want %s (%s)", loc.Path, loc.Source, want, tt.source)
+			}
+		})
+	}
+}
+
+// withSystemConfig points the system-wide default at path for one test.
+func withSystemConfig(t *testing.T, path string) {
+	t.Helper()
+	saved := systemConfigPath
+	systemConfigPath = func() string { return path }
+	t.Cleanup(func() { systemConfigPath = saved })
+}
+
+func TestLoadSeedsFromSystemConfig(t *testing.T) {
+	dir := t.TempDir()
+	system := filepath.Join(dir, "etc", "clock.json")
+	user := filepath.Join(dir, "clock.json")
+	withSystemConfig(t, system)
+
+	// No system file either: the built-in dashboard.
+	cfg, err := load(user)
+	if err != nil || !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Fatalf("load without any file = %+v, %v; want the default dashboard", cfg, err)
+	}
+
+	seed := `{"version": 2, "active": "ops", "boards": [{"name": "ops", "clocks": [{"label": "HQ", "location": "UTC"}]}]}`
+	if err := os.MkdirAll(filepath.Dir(system), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(system, []byte(seed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = load(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Selected() != "ops" || len(cfg.Boards) != 1 || cfg.Boards[0].Clocks[0].Label != "HQ" {
+		t.Errorf("load seeded %+v, want the system dashboard", cfg)
+	}
+
+	// Once the user file exists, the system one is ignored and untouched.
+	SetConfigPath(user)
+	t.Cleanup(func() { SetConfigPath("") })
+	if err := Save(DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := load(user); err != nil || cfg.Selected() != DefaultBoard {
+		t.Errorf("load after save = %+v, %v; want the saved dashboard", cfg, err)
+	}
+	if data, _ := os.ReadFile(system); string(data) != seed {
+		t.Errorf("system config was rewritten:\n%s", data)
+	}
+
+	// A broken system file falls back to the built-in dashboard, with an
+	// error, and is not copied aside.
+	if err := os.Remove(user); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(system, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = load(user)
+	if err == nil || errors.Is(err, ErrCorrupt) || !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("load with a broken system file = %+v, %v", cfg, err)
+	}
+	if m, _ := filepath.Glob(system + ".*"); len(m) != 0 {
+		t.Errorf("system config was copied aside: %v", m)
+	}
+}
@@ -1,7 +1,11 @@
 package store
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
@@ -10,56 +14,372 @@ import (
 // Entry is a single clock on the dashboard.
 type Entry struct {
 	Label    string `json:"label"`
-	Location string `json:"location"` // IANA name ("Europe/Istanbul") or "Local"
+	Location string `json:"location"`          // IANA name ("Europe/Istanbul") or "Local"
+	Hours    string `json:"hours,omitempty"`   // working windows, "09:00-17:00"; see DefaultHours
+	Weekend  string `json:"weekend,omitempty"` // weekday names, "Sat,Sun"; see DefaultWeekend
+	Status   string `json:"status,omitempty"`  // "ooo", "on-call" or a free-text note
+	Until    string `json:"until,omitempty"`   // YYYY-MM-DD the status lapses on
+	Group    string `json:"group,omitempty"`   // dashboard section, "EMEA"; "" is ungrouped
 }
 
 // Config is the persisted dashboard state.
 type Config struct {
-	Clocks []Entry `json:"clocks"`
+	Version int     `json:"version"`          // schema version; see CurrentVersion
+	Active  string  `json:"active,omitempty"` // board shown by default; see Selected
+	Boards  []Board `json:"boards"`
 }
 
-// ConfigPath returns the default config path: $HOME/.atlas/clock.json.
-func ConfigPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".atlas", "clock.json")
-}
+// ErrCorrupt is wrapped by Load when the config file exists but cannot be
+// parsed.
+var ErrCorrupt = errors.New("config is corrupt")
 
-// Load reads the config, returning a sensible default if the file doesn't exist.
-func Load() Config {
-	data, err := os.ReadFile(ConfigPath())
-	if err != nil {
-		return Config{Clocks: []Entry{
+// DefaultConfig is the dashboard used when no config file exists yet.
+func DefaultConfig() Config {
+	return Config{Version: CurrentVersion, Active: DefaultBoard, Boards: []Board{
+		{Name: DefaultBoard, Clocks: []Entry{
 			{Label: "Local", Location: "Local"},
 			{Label: "UTC", Location: "UTC"},
 			{Label: "Istanbul", Location: "Europe/Istanbul"},
-		}}
+		}},
+	}}
+}
+
+// ErrConflict is returned by Commit when another writer changed the config
+// in a way that can't be merged with ours.
+var ErrConflict = errors.New("config was changed concurrently by another instance")
+
+// Load reads the config. A missing file is not an error: the system-wide
+// default (see Location) is returned if there is one, otherwise the built-in
+// default dashboard. A file that can't be read or parsed also yields the
+// default dashboard, together with an error; for an unparsable file the error
+// wraps ErrCorrupt and the file is first copied aside (see backupCorrupt) so
+// a later Save can't destroy it.
+func Load() (Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return DefaultConfig(), err
+	}
+	return load(path)
+}
+
+func load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return loadSystem()
+	}
+	if err != nil {
+		return DefaultConfig(), fmt.Errorf("read config: %w", err)
+	}
+	cfg, err := decode(data)
+	if errors.Is(err, ErrTooNew) {
+		return DefaultConfig(), fmt.Errorf("%s: %w", path, err)
+	}
+	if err != nil {
+		bak, werr := backupCorrupt(path, data)
+		if werr != nil {
+			return DefaultConfig(), fmt.Errorf("%w: %s: %v (backup failed: %v)", ErrCorrupt, path, err, werr)
+		}
+		return DefaultConfig(), fmt.Errorf("%w: %s (copy kept at %s): %v", ErrCorrupt, path, bak, err)
+	}
+	return cfg, nil
+}
+
+// backupCorrupt copies an unparsable config to "<path>.<mtime>.bak", named
+// after the file's modification time so each bad write gets its own copy
+// and a load that fails again on the same one — every reload poll, say —
+// leaves the copy alone. Existing backups are never overwritten.
+func backupCorrupt(path string, data []byte) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	bak := fmt.Sprintf("%s.%s.bak", path, info.ModTime().UTC().Format("20060102-150405"))
+	f, err := os.OpenFile(bak, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return bak, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", err
+	}
+	return bak, f.Close()
+}
+
+// loadSystem reads the read-only, system-wide default dashboard, falling
+// back to the built-in one.
+func loadSystem() (Config, error) {
+	path := systemConfigPath()
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultConfig(), nil
+	}
+	if err != nil {
+		return DefaultConfig(), fmt.Errorf("read system config: %w", err)
+	}
+	cfg, err := decode(data)
+	if err != nil {
+		return DefaultConfig(), fmt.Errorf("system config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// decode migrates a raw document to CurrentVersion and parses it.
+func decode(data []byte) (Config, error) {
+	data, _, err := Migrate(data)
+	if err != nil {
+		return Config{}, err
 	}
 	var cfg Config
-	_ = json.Unmarshal(data, &cfg)
-	return cfg
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// encode renders cfg the way it is stored on disk, stamped with
+// CurrentVersion.
+func encode(cfg Config) ([]byte, error) {
+	cfg.Version = CurrentVersion
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// MigrateFile upgrades the config file on disk to CurrentVersion and returns
+// the document before and after (indented, keys sorted, so the two compare
+// line by line) along with the steps applied. With dryRun nothing is written; otherwise the old
+// file is kept as "<path>.v<N>.bak" before being replaced.
+func MigrateFile(dryRun bool) (before, after []byte, steps []string, err error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	unlock, err := lockConfig(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer unlock()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("read config: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %s: %v", ErrCorrupt, path, err)
+	}
+	from, err := docVersion(doc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if before, err = sortedIndent(raw); err != nil {
+		return nil, nil, nil, err
+	}
+
+	migrated, steps, err := Migrate(raw)
+	if err != nil {
+		return before, nil, steps, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(migrated, &cfg); err != nil {
+		return before, nil, steps, fmt.Errorf("%w: %s: %v", ErrCorrupt, path, err)
+	}
+	if after, err = encode(cfg); err != nil {
+		return before, nil, steps, err
+	}
+	if after, err = sortedIndent(after); err != nil {
+		return before, nil, steps, err
+	}
+	if dryRun || len(steps) == 0 {
+		return before, after, steps, nil
+	}
+
+	if err := os.WriteFile(fmt.Sprintf("%s.v%d.bak", path, from), raw, 0644); err != nil {
+		return before, after, steps, fmt.Errorf("backup config: %w", err)
+	}
+	return before, after, steps, write(path, cfg)
+}
+
+// sortedIndent re-indents a JSON document with object keys sorted.
+func sortedIndent(data []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(v, "", "  ")
 }
 
-// Save atomically persists the config (best-effort; errors are ignored to
-// keep the TUI responsive — the config is a convenience, not a source of truth).
+// Save overwrites the config with cfg under the config lock. Prefer Update
+// or Commit, which don't lose edits made by other instances.
 func Save(cfg Config) error {
-	path := ConfigPath()
-	_ = os.MkdirAll(filepath.Dir(path), 0755)
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	path, err := ConfigPath()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0644)
+	unlock, err := lockConfig(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return write(path, cfg)
+}
+
+// Update runs a read-modify-write cycle under the config lock, so concurrent
+// instances can't interleave between reading and writing. It refuses to run
+// against a file Load can't read.
+func Update(fn func(*Config) error) (Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+	unlock, err := lockConfig(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer unlock()
+
+	cfg, err := load(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := fn(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, write(path, cfg)
 }
 
-// Now returns the current time in the entry's zone. Invalid zones fall back
-// to local time so a malformed config never crashes the UI.
-func (e Entry) Now() time.Time {
-	if e.Location == "" || e.Location == "Local" {
-		return time.Now()
+// Commit persists next, an edit of base (the config as this instance last
+// read or wrote it). If the file changed on disk since, the two edits are
+// merged three-way, board by board; the merged config is written and
+// returned. When the edits can't be merged, the file is left alone and the
+// on-disk config is returned with ErrConflict. Either way the Stamp is that
+// of the returned config's file, taken under the lock, so a write by
+// another instance right after can't be mistaken for this one.
+func Commit(base, next Config) (Config, Stamp, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return next, Stamp{}, err
+	}
+	unlock, err := lockConfig(path)
+	if err != nil {
+		return next, Stamp{}, err
+	}
+	defer unlock()
+
+	theirs, err := load(path)
+	switch {
+	case errors.Is(err, ErrCorrupt):
+		// Load has already kept a backup; nothing on disk is worth merging.
+		theirs = base
+	case err != nil:
+		return next, Stamp{}, err
+	}
+
+	merged, ok := mergeBoards(base.Boards, next.Boards, theirs.Boards)
+	if !ok {
+		return theirs, stampFile(path), ErrConflict
+	}
+	out := next
+	out.Boards = merged
+	if next.Active == base.Active {
+		out.Active = theirs.Active
+	}
+	if err := write(path, out); err != nil {
+		return out, Stamp{}, err
+	}
+	return out, stampFile(path), nil
+}
+
+// write atomically replaces the file at path: the JSON goes to a temp file
+// in the same directory, which is synced and then renamed over the target.
+func write(path string, cfg Config) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	data, err := encode(cfg)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save config: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	return nil
+}
+
+// At returns the instant t expressed in the entry's zone. Invalid zones fall
+// back to local time so a malformed config never crashes the UI; callers
+// that show the result check Zone for the error and flag it.
+func (e Entry) At(t time.Time) time.Time {
+	loc, err := e.Zone()
+	if err != nil {
+		return t.Local()
+	}
+	return t.In(loc)
+}
+
+// Zone resolves the entry's location through the LoadZone cache; "" and
+// "Local" mean time.Local.
+func (e Entry) Zone() (*time.Location, error) {
+	return LoadZone(e.Location)
+}
+
+// Stamp identifies one version of the config file, for noticing outside
+// edits: its modification time and a hash of its contents, since two writes
+// can land in the same mtime tick. The zero Stamp stands for no file.
+type Stamp struct {
+	modTime time.Time
+	sum     [sha256.Size]byte
+}
+
+// Equal reports whether s and o are the same version of the file.
+func (s Stamp) Equal(o Stamp) bool {
+	return s.modTime.Equal(o.modTime) && s.sum == o.sum
+}
+
+// CurrentStamp returns the config file's Stamp, or the zero Stamp if it
+// doesn't exist. The file is small, so it's cheap enough to poll.
+func CurrentStamp() Stamp {
+	path, err := ConfigPath()
+	if err != nil {
+		return Stamp{}
+	}
+	return stampFile(path)
+}
+
+func stampFile(path string) Stamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Stamp{}
 	}
-	loc, err := time.LoadLocation(e.Location)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return time.Now()
+		return Stamp{}
 	}
-	return time.Now().In(loc)
+	return Stamp{modTime: info.ModTime(), sum: sha256.Sum256(data)}
 }
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCorruptKeepsEveryBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clock.json")
+	write := func(data string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backups := func() []string {
+		t.Helper()
+		m, err := filepath.Glob(path + ".*.bak")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	first := time.Date(2026, 7, 15, 9, 30, 0, 0, time.UTC)
+	write("{not json", first)
+	for range 3 {
+		if _, err := load(path); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("load = %v, want ErrCorrupt", err)
+		}
+	}
+	if got := backups(); len(got) != 1 {
+		t.Fatalf("backups after repeated loads = %v, want one", got)
+	}
+
+	write("{still not json", first.Add(time.Minute))
+	if _, err := load(path); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("load = %v, want ErrCorrupt", err)
+	}
+	got := backups()
+	if len(got) != 2 {
+		t.Fatalf("backups after a second bad write = %v, want two", got)
+	}
+	data, err := os.ReadFile(path + ".20260715-093000.bak")
+	if err != nil || string(data) != "{not json" {
+		t.Errorf("first backup = %q, %v; want the first bad write", data, err)
+	}
+}
+
+func TestStampSeesSameTickWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clock.json")
+	mod := time.Date(2026, 7, 15, 9, 30, 0, 0, time.UTC)
+	stamp := func(data string) Stamp {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+		return stampFile(path)
+	}
+	a := stamp(`{"active":"team"}`)
+	if b := stamp(`{"active":"team"}`); !a.Equal(b) {
+		t.Error("rewriting the same bytes in the same tick changed the stamp")
+	}
+	// Same size, same mtime, different contents.
+	if b := stamp(`{"active":"tram"}`); a.Equal(b) {
+		t.Error("a same-size write in the same mtime tick went unnoticed")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if !stampFile(path).Equal(Stamp{}) {
+		t.Error("a missing file should have the zero stamp")
+	}
+}
@@ -0,0 +1,49 @@
+package store
+
+import "fmt"
+
+// Phase is a coarse day/night classification of a wall-clock hour.
+type Phase string
+
+const (
+	PhaseDay      Phase = "day"
+	PhaseTwilight Phase = "twilight"
+	PhaseNight    Phase = "night"
+)
+
+// PhaseAt classifies a local hour: 06–18 day, 04–06 and 18–22 twilight,
+// everything else night.
+func PhaseAt(hour int) Phase {
+	switch {
+	case hour >= 6 && hour < 18:
+		return PhaseDay
+	case hour >= 18 && hour < 22, hour >= 4 && hour < 6:
+		return PhaseTwilight
+	default:
+		return PhaseNight
+	}
+}
+
+// Glyph returns the sun/moon glyph used for the phase across the UI and CLI.
+func (p Phase) Glyph() string {
+	switch p {
+	case PhaseDay:
+		return "☀"
+	case PhaseTwilight:
+		return "☽"
+	default:
+		return "☾"
+	}
+}
+
+// FormatOffset renders a UTC offset in seconds as "UTC+03:00".
+func FormatOffset(off int) string {
+	sign := "+"
+	if off < 0 {
+		sign = "-"
+		off = -off
+	}
+	h := off / 3600
+	min := (off % 3600) / 60
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, h, min)
+}
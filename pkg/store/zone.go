@@ -0,0 +1,100 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownZone is wrapped by LoadZone for names the runtime has no
+// zoneinfo for.
+var ErrUnknownZone = errors.New("unknown zone")
+
+// zones caches LoadZone results, failures included, by name.
+// time.LoadLocation reads and parses a zoneinfo file on every call, and the
+// dashboard resolves every clock several times a frame. Names are what the
+// cache is keyed on, so an edited config resolves its new zones on first
+// use and never sees stale ones.
+var zones = struct {
+	sync.Mutex
+	m       map[string]zone
+	suggest map[string]string
+}{m: map[string]zone{}, suggest: map[string]string{}}
+
+type zone struct {
+	loc *time.Location
+	err error
+}
+
+// LoadZone resolves an IANA zone name, once per name per process. "" and
+// "Local" mean time.Local.
+func LoadZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	zones.Lock()
+	defer zones.Unlock()
+	z, ok := zones.m[name]
+	if !ok {
+		z.loc, z.err = time.LoadLocation(name)
+		if z.err != nil {
+			z.err = fmt.Errorf("%w %q", ErrUnknownZone, name)
+		}
+		zones.m[name] = z
+	}
+	return z.loc, z.err
+}
+
+// SuggestZone returns the zone in IANAZones closest to a name that didn't
+// resolve — "Europe/Istambul" gives "Europe/Istanbul", "new york" gives
+// "America/New_York" — or "" when nothing is close. Names without a region
+// are matched against the city part too.
+func SuggestZone(name string) string {
+	zones.Lock()
+	defer zones.Unlock()
+	if s, ok := zones.suggest[name]; ok {
+		return s
+	}
+
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	best, bestDist := "", -1
+	for _, z := range IANAZones {
+		lz := strings.ToLower(z)
+		d := editDistance(key, lz)
+		if i := strings.LastIndexByte(lz, '/'); i >= 0 && !strings.Contains(key, "/") {
+			d = min(d, editDistance(key, lz[i+1:]))
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = z, d
+		}
+	}
+	// Allow a typo or two, more in longer names.
+	if bestDist > max(2, len(key)/4) {
+		best = ""
+	}
+	zones.suggest[name] = best
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b, in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadZone(t *testing.T) {
+	for _, name := range []string{"", "Local", "UTC", "Asia/Kolkata"} {
+		if _, err := LoadZone(name); err != nil {
+			t.Errorf("LoadZone(%q): %v", name, err)
+		}
+	}
+	if _, err := LoadZone("Europe/Istambul"); !errors.Is(err, ErrUnknownZone) {
+		t.Errorf("LoadZone(%q) = %v, want ErrUnknownZone", "Europe/Istambul", err)
+	}
+}
+
+func TestSuggestZone(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"Europe/Istambul", "Europe/Istanbul"},
+		{"europe/istanbul", "Europe/Istanbul"},
+		{"Amerika/New_York", "America/New_York"},
+		{"new york", "America/New_York"},
+		{"Tokio", "Asia/Tokyo"},
+		{"Kathmandoo", "Asia/Kathmandu"},
+		{"Nowhere/Special", ""},
+		{"xyz", ""},
+	}
+	for _, tt := range tests {
+		if got := SuggestZone(tt.name); got != tt.want {
+			t.Errorf("SuggestZone(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// brailleBits maps a dot at (x%2, y%4) within a cell to its bit in the
+// U+2800 braille block.
+var brailleBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// canvas is a bitmap drawn with braille characters, 2×4 dots per cell, in
+// layers: each cell takes the style of the topmost layer with a dot in it.
+type canvas struct {
+	cols, rows int
+	layers     []canvasLayer
+}
+
+type canvasLayer struct {
+	style lipgloss.Style
+	dots  map[[2]int]bool
+}
+
+func newCanvas(cols, rows int) *canvas {
+	return &canvas{cols: cols, rows: rows}
+}
+
+// layer starts a new layer above the existing ones and returns its plot
+// function; dots outside the canvas are dropped.
+func (c *canvas) layer(style lipgloss.Style) func(x, y float64) {
+	l := canvasLayer{style: style, dots: map[[2]int]bool{}}
+	c.layers = append(c.layers, l)
+	return func(x, y float64) {
+		xi, yi := int(math.Round(x)), int(math.Round(y))
+		if xi < 0 || yi < 0 || xi >= c.cols*2 || yi >= c.rows*4 {
+			return
+		}
+		l.dots[[2]int{xi, yi}] = true
+	}
+}
+
+// line plots a straight line from (x0, y0) to (x1, y1).
+func line(plot func(x, y float64), x0, y0, x1, y1 float64) {
+	steps := math.Max(math.Abs(x1-x0), math.Abs(y1-y0))
+	if steps < 1 {
+		plot(x0, y0)
+		return
+	}
+	for i := 0.0; i <= steps; i++ {
+		plot(x0+(x1-x0)*i/steps, y0+(y1-y0)*i/steps)
+	}
+}
+
+func (c *canvas) String() string {
+	out := make([]string, c.rows)
+	for row := 0; row < c.rows; row++ {
+		var b strings.Builder
+		for col := 0; col < c.cols; col++ {
+			var bits rune
+			top := -1
+			for li, l := range c.layers {
+				for dy := 0; dy < 4; dy++ {
+					for dx := 0; dx < 2; dx++ {
+						if l.dots[[2]int{col*2 + dx, row*4 + dy}] {
+							bits |= brailleBits[dy][dx]
+							top = li
+						}
+					}
+				}
+			}
+			if top < 0 {
+				b.WriteByte(' ')
+				continue
+			}
+			b.WriteString(c.layers[top].style.Render(string(0x2800 + bits)))
+		}
+		out[row] = b.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+// layout is how the dashboard draws its clocks; v cycles through them.
+type layout int
+
+const (
+	layoutCards layout = iota
+	layoutDials        // cards with an analog dial
+	layoutList         // one line per clock
+	layoutCount
+)
+
+func (l layout) String() string {
+	switch l {
+	case layoutDials:
+		return "DIALS"
+	case layoutList:
+		return "LIST"
+	default:
+		return "GRID"
+	}
+}
+
+// Size of the analog dial on a card and in the detail view, in cells.
+const (
+	dialCols       = 12
+	dialRows       = 6
+	detailDialCols = 24
+	detailDialRows = 12
+)
+
+// renderDial draws an analog clock face showing t, cols×rows cells. Braille
+// dots are roughly square, so a dial twice as wide as it is tall is round.
+// With smooth, the second hand sweeps instead of ticking.
+func renderDial(t time.Time, cols, rows int, smooth bool) string {
+	c := newCanvas(cols, rows)
+	cx, cy := float64(cols*2-1)/2, float64(rows*4-1)/2
+	r := math.Min(cx, cy)
+
+	// point is the dot at fraction f of the way round the dial (0 = 12
+	// o'clock), length l from the centre.
+	point := func(f, l float64) (float64, float64) {
+		a := 2 * math.Pi * f
+		return cx + l*math.Sin(a), cy - l*math.Cos(a)
+	}
+
+	rim := c.layer(sBorder)
+	for i := 0; i < 120; i++ {
+		rim(point(float64(i)/120, r))
+	}
+	// Hour ticks; small dials only get a dot at 12, 3, 6 and 9 so the
+	// hands stay readable.
+	ticks := c.layer(sDim)
+	for h := 0; h < 12; h++ {
+		switch {
+		case r >= 12:
+			inner := 0.88
+			if h%3 == 0 {
+				inner = 0.75
+			}
+			x0, y0 := point(float64(h)/12, r*inner)
+			x1, y1 := point(float64(h)/12, r)
+			line(ticks, x0, y0, x1, y1)
+		case h%3 == 0:
+			ticks(point(float64(h)/12, r-2))
+		}
+	}
+
+	sec := float64(t.Second())
+	if smooth {
+		sec += float64(t.Nanosecond()) / 1e9
+	}
+	mins := float64(t.Minute()) + sec/60
+	hour := float64(t.Hour()%12) + mins/60
+
+	hands := []struct {
+		style lipgloss.Style
+		f, l  float64
+	}{
+		{sPaper, hour / 12, 0.5},
+		{sAmber, mins / 60, 0.8},
+		{sHot, sec / 60, 0.9},
+	}
+	for _, h := range hands {
+		x, y := point(h.f, r*h.l)
+		line(c.layer(h.style), cx, cy, x, y)
+	}
+	return c.String()
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// boardNames lists the boards in tab order. A board picked with --board that
+// doesn't exist on disk yet is shown last until its first save creates it.
+func (m model) boardNames() []string {
+	names := make([]string, 0, len(m.base.Boards)+1)
+	for _, b := range m.base.Boards {
+		names = append(names, b.Name)
+	}
+	if !slices.Contains(names, m.board) {
+		names = append(names, m.board)
+	}
+	return names
+}
+
+// boardClocks returns a copy of the named board's clocks as last read from
+// or written to disk.
+func (m model) boardClocks(name string) []store.Entry {
+	if b := m.base.Board(name); b != nil {
+		return append([]store.Entry(nil), b.Clocks...)
+	}
+	return nil
+}
+
+// switchBoard shows the board at index i of boardNames, wrapping around.
+func (m *model) switchBoard(i int) {
+	names := m.boardNames()
+	i = (i%len(names) + len(names)) % len(names)
+	if names[i] == m.board {
+		return
+	}
+	m.board = names[i]
+	m.clocks = m.boardClocks(m.board)
+	m.cursor = 0
+}
+
+// boardIndex is the position of the shown board in boardNames.
+func (m model) boardIndex() int {
+	return slices.Index(m.boardNames(), m.board)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// noColor drops the palette while keeping bold, reverse and underline; set
+// by SetColorMode.
+var noColor bool
+
+// SetColorMode applies the --color flag. "auto" (the default) uses what the
+// terminal supports and honours NO_COLOR; "always" forces color even when
+// NO_COLOR is set or the output isn't a terminal; "never" draws in
+// monochrome.
+//
+// Monochrome keeps the terminal's own profile and blanks the palette
+// instead: lipgloss's no-color profile would strip text attributes too,
+// and those carry the signals color can't.
+func SetColorMode(mode string) error {
+	out := termenv.NewOutput(os.Stdout)
+	switch mode {
+	case "", "auto":
+		noColor = out.EnvNoColor()
+		if !noColor {
+			return nil // lipgloss detects the profile, CLICOLOR_FORCE included
+		}
+	case "always":
+		noColor = false
+	case "never":
+		noColor = true
+	default:
+		return fmt.Errorf("invalid color mode %q (want auto, always or never)", mode)
+	}
+
+	p := out.ColorProfile()
+	if p == termenv.Ascii && mode == "always" {
+		p = termenv.ANSI256
+	}
+	lipgloss.SetColorProfile(p)
+	return nil
+}
+
+// monochrome reports whether styles render without color, so every signal
+// the palette carries needs a glyph or text attribute as well.
+func monochrome() bool {
+	return noColor || lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// shade is a theme color that falls back to bright black on 16-color
+// terminals; the nearest basic color to the palettes' dark chrome and dim
+// shades is black, which would hide borders and labels.
+func shade(c lipgloss.Color) lipgloss.TerminalColor {
+	if noColor {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.CompleteColor{TrueColor: string(c), ANSI256: string(c), ANSI: "8"}
+}
+
+// signal is a theme color that carries meaning — accent, alert, good — with
+// its 256- and 16-color forms given explicitly, so they aren't left to
+// whichever basic color happens to be nearest: that can turn an amber
+// accent into a dull olive, or a red into a magenta. Without stand-ins
+// (a user's own color) it is the hue.
+func signal(c lipgloss.Color, b basic) lipgloss.TerminalColor {
+	if noColor || b == (basic{}) {
+		return hue(c)
+	}
+	return lipgloss.CompleteColor{TrueColor: string(c), ANSI256: b.ansi256, ANSI: b.ansi}
+}
+
+// hue is a theme color as is, or none in monochrome.
+func hue(c lipgloss.Color) lipgloss.TerminalColor {
+	if noColor {
+		return lipgloss.NoColor{}
+	}
+	return c
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// minWidth is the narrowest terminal the UI renders in at all.
+	minWidth = 32
+	// compactBelow is the width under which the dashboard switches to the
+	// one-line-per-clock list whatever v says.
+	compactBelow = 64
+	// badgeW is the width of the list's availability column: the widest
+	// Availability.Short, "ON-CALL".
+	badgeW = 7
+)
+
+// listView reports whether the dashboard shows the compact list instead of
+// the card grid: picked with v, and forced in narrow terminals.
+func (m model) listView() bool {
+	return m.layout == layoutList || m.width < compactBelow
+}
+
+// renderListLine draws the clock at index j as one line of the compact
+// list, width cells wide: marker, glyph, label, time, offset, delta to local
+// time and, when there's room, the availability badge.
+func (m model) renderListLine(j, width int, now time.Time) string {
+	entry := m.clocks[j]
+	t := entry.At(now)
+	dnGlyph, dnStyle := daynightStyle(t.Hour())
+	_, off := t.Zone()
+	_, localOff := now.Local().Zone()
+	avail := entry.AvailabilityAt(now)
+
+	marker, labelStyle := "  ", sPaper
+	if j == m.cursor {
+		marker, labelStyle = sAmber.Render("▸ "), sAmber
+	}
+
+	// Right-hand columns; the badge, then the offset, then the delta are
+	// dropped until the label gets a readable share of the line.
+	clock := sBigDigit.Render(t.Format("15:04:05"))
+	offset := sDim.Render(padLeft(store.FormatOffset(off), 9))
+	delta := sValue.Render(padLeft(formatDelta(off-localOff), 6))
+	badge := availabilityStyle(avail).Render(padLeft(avail.Short(), badgeW))
+	glyph := dnStyle.Render(dnGlyph)
+	if _, err := entry.Zone(); err != nil {
+		// No time for an unknown zone rather than local time under the
+		// wrong label.
+		glyph, clock = sCrit.Render("⚠"), sCrit.Render("--:--:--")
+		offset = sCrit.Render(padLeft("UNKNOWN", 9))
+		delta, badge = padLeft("", 6), padLeft("", badgeW)
+	}
+	const minLabel = 8
+	var right string
+	for _, fields := range [][]string{
+		{clock, offset, delta, badge},
+		{clock, offset, delta},
+		{clock, delta},
+		{clock},
+	} {
+		right = strings.Join(fields, "  ")
+		if width-5-lipgloss.Width(right)-1 >= minLabel {
+			break
+		}
+	}
+
+	labelW := max(3, width-5-lipgloss.Width(right)-1)
+	label := labelStyle.Render(padLeft(truncateVisible(entry.Label, labelW), labelW))
+	return marker + glyph + "  " + label + " " + right
+}
+
+// formatDelta renders an offset difference to local time: "+9h", "-5h30m",
+// "±0".
+func formatDelta(seconds int) string {
+	if seconds == 0 {
+		return "±0"
+	}
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	h, m := seconds/3600, seconds%3600/60
+	if m == 0 {
+		return fmt.Sprintf("%s%dh", sign, h)
+	}
+	return fmt.Sprintf("%s%dh%02dm", sign, h, m)
+}
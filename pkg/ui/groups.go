@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// group is one titled section of the dashboard: the clocks sharing an
+// Entry.Group, as indices into m.clocks in config order.
+type group struct {
+	name   string
+	clocks []int
+}
+
+// title is the section heading; ungrouped clocks share one section.
+func (g group) title() string {
+	if g.name == "" {
+		return "UNGROUPED"
+	}
+	return g.name
+}
+
+// groups splits the clocks into sections, ordered by each group's first
+// appearance in the config.
+func (m model) groups() []group {
+	var out []group
+	at := map[string]int{}
+	for i, e := range m.clocks {
+		g, ok := at[e.Group]
+		if !ok {
+			g = len(out)
+			at[e.Group] = g
+			out = append(out, group{name: e.Group})
+		}
+		out[g].clocks = append(out[g].clocks, i)
+	}
+	return out
+}
+
+// grouped reports whether the dashboard is split into sections at all;
+// without any groups it stays one flat grid.
+func (m model) grouped() bool {
+	for _, e := range m.clocks {
+		if e.Group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// stopRows lays every group out as grid rows of cursor stops (clock
+// indices). A collapsed group is a single stop on its first clock.
+func (m model) stopRows() [][][]int {
+	cols := m.gridCols()
+	groups := m.groups()
+	out := make([][][]int, len(groups))
+	for gi, g := range groups {
+		if m.collapsed[g.name] && m.grouped() {
+			out[gi] = [][]int{{g.clocks[0]}}
+			continue
+		}
+		for i := 0; i < len(g.clocks); i += cols {
+			out[gi] = append(out[gi], g.clocks[i:min(i+cols, len(g.clocks))])
+		}
+	}
+	return out
+}
+
+// locate finds the cursor's group, row and column in stopRows. A cursor on
+// a hidden clock of a collapsed group sits on that group's stop.
+func (m model) locate(rows [][][]int) (gi, r, c int, ok bool) {
+	for gi, g := range m.groups() {
+		for _, i := range g.clocks {
+			if i != m.cursor {
+				continue
+			}
+			for r, row := range rows[gi] {
+				for c, stop := range row {
+					if stop == m.cursor {
+						return gi, r, c, true
+					}
+				}
+			}
+			return gi, 0, 0, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// neighbour returns the clock a cursor move in dir ("up", "down", "left",
+// "right") lands on, crossing group boundaries, or -1 at the edges. Moving
+// up or down keeps the column where the next row is long enough.
+func (m model) neighbour(dir string) int {
+	rows := m.stopRows()
+	gi, r, c, ok := m.locate(rows)
+	if !ok {
+		return -1
+	}
+	switch dir {
+	case "left", "right":
+		var flat []int
+		at := 0
+		for g := range rows {
+			for rr, row := range rows[g] {
+				if g == gi && rr == r {
+					at = len(flat) + c
+				}
+				flat = append(flat, row...)
+			}
+		}
+		if dir == "left" {
+			at--
+		} else {
+			at++
+		}
+		if at < 0 || at >= len(flat) {
+			return -1
+		}
+		return flat[at]
+	case "up":
+		if r > 0 {
+			r--
+		} else if gi > 0 {
+			gi--
+			r = len(rows[gi]) - 1
+		} else {
+			return -1
+		}
+	case "down":
+		if r < len(rows[gi])-1 {
+			r++
+		} else if gi < len(rows)-1 {
+			gi, r = gi+1, 0
+		} else {
+			return -1
+		}
+	}
+	row := rows[gi][r]
+	return row[min(c, len(row)-1)]
+}
+
+// reorder moves the selected clock in dir. Within a group it swaps places
+// with its neighbour; across a group boundary it joins the neighbouring
+// group, at its end when moving up or left and at its start otherwise.
+func (m *model) reorder(dir string) {
+	t := m.neighbour(dir)
+	if t < 0 || m.collapsed[m.clocks[m.cursor].Group] && m.grouped() {
+		return
+	}
+	m.record("MOVE " + m.clocks[m.cursor].Label)
+	target := m.clocks[t].Group
+	if target == m.clocks[m.cursor].Group {
+		m.clocks[m.cursor], m.clocks[t] = m.clocks[t], m.clocks[m.cursor]
+		m.cursor = t
+		m.save()
+		return
+	}
+
+	e := m.clocks[m.cursor]
+	e.Group = target
+	rest := append(m.clocks[:m.cursor:m.cursor], m.clocks[m.cursor+1:]...)
+	at := len(rest)
+	for i, c := range rest {
+		if c.Group != target {
+			continue
+		}
+		if dir == "down" || dir == "right" {
+			at = i
+			break
+		}
+		at = i + 1
+	}
+	m.clocks = append(rest[:at:at], append([]store.Entry{e}, rest[at:]...)...)
+	m.cursor = at
+	m.save()
+}
+
+// toggleCollapse folds or unfolds the selected clock's group.
+func (m *model) toggleCollapse() {
+	if !m.grouped() || m.cursor >= len(m.clocks) {
+		return
+	}
+	name := m.clocks[m.cursor].Group
+	if m.collapsed == nil {
+		m.collapsed = map[string]bool{}
+	}
+	m.collapsed[name] = !m.collapsed[name]
+	if m.collapsed[name] {
+		for _, g := range m.groups() {
+			if g.name == name {
+				m.cursor = g.clocks[0]
+			}
+		}
+	}
+}
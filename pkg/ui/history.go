@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// historyLimit caps how many undo steps are kept in memory.
+const historyLimit = 100
+
+// snapshot is one restorable dashboard state, taken just before a mutation.
+type snapshot struct {
+	action string // what the mutation did, e.g. "DELETE Tokyo"
+	board  string
+	clocks []store.Entry
+	cursor int
+}
+
+// history holds the undo and redo stacks for dashboard mutations.
+type history struct {
+	undo, redo []snapshot
+}
+
+func (m model) snapshot(action string) snapshot {
+	return snapshot{
+		action: action,
+		board:  m.board,
+		clocks: append([]store.Entry(nil), m.clocks...),
+		cursor: m.cursor,
+	}
+}
+
+// record pushes the current state onto the undo stack before a mutation
+// described by action, and drops any redo steps.
+func (m *model) record(action string) {
+	m.history.undo = append(m.history.undo, m.snapshot(action))
+	if len(m.history.undo) > historyLimit {
+		m.history.undo = m.history.undo[len(m.history.undo)-historyLimit:]
+	}
+	m.history.redo = nil
+}
+
+// undo restores the state before the last mutation.
+func (m *model) undo() {
+	n := len(m.history.undo)
+	if n == 0 {
+		return
+	}
+	prev := m.history.undo[n-1]
+	m.history.undo = m.history.undo[:n-1]
+	m.history.redo = append(m.history.redo, m.snapshot(prev.action))
+	m.restore(prev)
+}
+
+// redo re-applies the last undone mutation.
+func (m *model) redo() {
+	n := len(m.history.redo)
+	if n == 0 {
+		return
+	}
+	next := m.history.redo[n-1]
+	m.history.redo = m.history.redo[:n-1]
+	m.history.undo = append(m.history.undo, m.snapshot(next.action))
+	m.restore(next)
+}
+
+// restore brings back a snapshot, switching to the board it was taken on.
+func (m *model) restore(s snapshot) {
+	m.board = s.board
+	m.clocks = append([]store.Entry(nil), s.clocks...)
+	m.cursor = s.cursor
+	if m.cursor >= len(m.clocks) {
+		m.cursor = len(m.clocks) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.save()
+}
+
+// save persists the dashboard, merging with any edits another instance made
+// since we last read or wrote the file. Failures are recorded for the
+// masthead; on a conflict the on-disk dashboard is adopted. Undo history
+// is dropped whenever another instance's edits come in with the result, as
+// on a reload.
+func (m *model) save() {
+	next := m.base.WithBoard(m.board, m.clocks)
+	merged, stamp, err := store.Commit(m.base, next)
+	switch {
+	case errors.Is(err, store.ErrConflict):
+		m.saveErr = "another instance reordered or edited the same clocks — reloaded from disk, your last change was dropped"
+		m.history = history{}
+		m.adopt(merged)
+	case err != nil:
+		m.saveErr = err.Error()
+	default:
+		m.saveErr = ""
+		if !slices.EqualFunc(merged.Boards, next.Boards, store.Board.Equal) {
+			m.history = history{}
+		}
+		m.adopt(merged)
+	}
+	// Our own write (or the conflicting one we just adopted) is not news.
+	// After a failed write the file is as it was, and still news if it was.
+	if err == nil || errors.Is(err, store.ErrConflict) {
+		m.cfgStamp = stamp
+	}
+}
+
+// adopt makes cfg the known on-disk state and shows the current board from
+// it, keeping the cursor in range.
+func (m *model) adopt(cfg store.Config) {
+	m.base = cfg
+	m.clocks = m.boardClocks(m.board)
+	m.badZones = unknownZones(cfg)
+	if m.cursor >= len(m.clocks) {
+		m.cursor = len(m.clocks) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
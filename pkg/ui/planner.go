@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// plannerSlots is the number of one-hour columns on the planner timeline.
+const plannerSlots = 24
+
+// plannerStart is the UTC hour the timeline begins at: the hour containing
+// the (possibly time-travelled) current instant.
+func (m model) plannerStart() time.Time {
+	return m.now().UTC().Truncate(time.Hour)
+}
+
+// slotWorking reports whether the entry is available for the whole hour
+// starting at t — inside working hours, not on a weekend, not out of office.
+func slotWorking(e store.Entry, t time.Time) bool {
+	return e.AvailabilityAt(t) == store.Available &&
+		e.AvailabilityAt(t.Add(time.Hour-time.Minute)) == store.Available
+}
+
+// slotOverlap reports whether every included clock is working for the hour
+// starting at t. A planner with nothing included has no overlap.
+func (m model) slotOverlap(t time.Time) bool {
+	included := false
+	for i, e := range m.clocks {
+		// A clock with an unknown zone has no hours to overlap.
+		if _, err := e.Zone(); m.excluded[i] || err != nil {
+			continue
+		}
+		if !slotWorking(e, t) {
+			return false
+		}
+		included = true
+	}
+	return included
+}
+
+func (m model) keyPlanner(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc", "p":
+		m.state = viewDashboard
+	case "left", "h":
+		if m.slot > 0 {
+			m.slot--
+		}
+	case "right", "l":
+		if m.slot < plannerSlots-1 {
+			m.slot++
+		}
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.clocks)-1 {
+			m.cursor++
+		}
+	case " ":
+		if m.cursor < len(m.clocks) {
+			m.excluded[m.cursor] = !m.excluded[m.cursor]
+		}
+	case "*":
+		m.excluded = map[int]bool{}
+	case "n":
+		m.shift = 0
+	}
+	return m, nil
+}
+
+func (m model) renderPlanner() string {
+	if len(m.clocks) == 0 {
+		return section("01", "MEETING PLANNER", sDim.Render("no clocks — press ESC, then A to add one"), m.width)
+	}
+
+	const labelW = 14
+	inner := m.width - 4
+	cellW := 3
+	if inner-labelW-2 < plannerSlots*cellW {
+		cellW = 2
+	}
+	start := m.plannerStart()
+	slotAt := func(col int) time.Time { return start.Add(time.Duration(col) * time.Hour) }
+
+	cell := func(s string, st lipgloss.Style, col int) string {
+		s = padRight(s, cellW)
+		if col == m.slot {
+			st = st.Reverse(true)
+		}
+		return st.Render(s)
+	}
+
+	// UTC ruler.
+	var ruler strings.Builder
+	ruler.WriteString(padLeft(sLabel.Render("  UTC"), labelW+2))
+	for col := 0; col < plannerSlots; col++ {
+		st := sDim
+		if col == m.slot {
+			st = sAmber
+		}
+		ruler.WriteString(cell(slotAt(col).Format("15"), st, col))
+	}
+	lines := []string{ruler.String()}
+	if m.shift != 0 {
+		// The columns start from the scrubbed time, so say so.
+		lines = append([]string{m.renderScrubBanner(m.now()), ""}, lines...)
+	}
+
+	// One row per clock, local hours shaded by working time.
+	for i, e := range m.clocks {
+		mark, labelStyle := "●", sPaper
+		if m.excluded[i] {
+			mark, labelStyle = "○", sDim
+		}
+		if i == m.cursor {
+			labelStyle = sAmber
+			if monochrome() {
+				labelStyle = labelStyle.Reverse(true)
+			}
+		}
+		var row strings.Builder
+		row.WriteString(labelStyle.Render(mark+" ") +
+			padLeft(labelStyle.Render(truncateVisible(e.Label, labelW-1)), labelW))
+		_, zoneErr := e.Zone()
+		for col := 0; col < plannerSlots; col++ {
+			t := slotAt(col)
+			if zoneErr != nil {
+				row.WriteString(cell("?", sCrit, col))
+				continue
+			}
+			st := sDim
+			if slotWorking(e, t) {
+				// Working hours are underlined when there's no green.
+				st = sGood.Underline(monochrome())
+			}
+			row.WriteString(cell(e.At(t).Format("15"), st, col))
+		}
+		lines = append(lines, row.String())
+	}
+
+	// Overlap strip.
+	var strip strings.Builder
+	strip.WriteString(padLeft(sLabel.Render("  OVERLAP"), labelW+2))
+	overlap := 0
+	for col := 0; col < plannerSlots; col++ {
+		if m.slotOverlap(slotAt(col)) {
+			overlap++
+			strip.WriteString(cell(strings.Repeat("▀", cellW-1), sAmber, col))
+		} else {
+			strip.WriteString(cell("", sDim, col))
+		}
+	}
+	lines = append(lines, strip.String(), "")
+
+	summary := sDim.Render("no common working hours")
+	if overlap > 0 {
+		summary = sGood.Render(fmt.Sprintf("%dh common working time", overlap))
+	}
+	lines = append(lines, summary)
+	timeline := section("01", "MEETING PLANNER", strings.Join(lines, "\n"), m.width)
+
+	// Selected slot in every zone.
+	t := slotAt(m.slot)
+	slotLines := []string{
+		labelValue("SLOT", sValue.Render(t.Format("15:04")+"–"+t.Add(time.Hour).Format("15:04")+" UTC"), 12),
+		"",
+	}
+	for i, e := range m.clocks {
+		if _, err := e.Zone(); err != nil {
+			slotLines = append(slotLines, sCrit.Render("⚠")+"  "+
+				padLeft(sPaper.Render(truncateVisible(e.Label, labelW)), labelW+1)+
+				sCrit.Render("UNKNOWN ZONE "+e.Location))
+			continue
+		}
+		local := e.At(t)
+		_, off := local.Zone()
+		dnGlyph, dnStyle := daynightStyle(local.Hour())
+		avail := e.AvailabilityAt(t)
+		status := availabilityStyle(avail).Render(string(avail))
+		if m.excluded[i] {
+			status = sDim.Render("(excluded)")
+		}
+		slotLines = append(slotLines, dnStyle.Render(dnGlyph)+"  "+
+			padLeft(sPaper.Render(truncateVisible(e.Label, labelW)), labelW+1)+
+			horiz(
+				sValue.Render(local.Format("Mon 02 Jan 15:04")),
+				sDim.Render(store.FormatOffset(off)),
+				status,
+			))
+	}
+	detail := section("02", "SELECTED SLOT", strings.Join(slotLines, "\n"), m.width)
+
+	return timeline + "\n" + detail
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+const (
+	// reloadPoll is how often the config file is checked for outside edits.
+	reloadPoll = time.Second
+	// noticeFor is how long a masthead notice stays up.
+	noticeFor = 3 * time.Second
+)
+
+// pollConfig reloads the dashboard when the config changed on disk — edited
+// by hand, by a dotfiles sync or by another instance. Mid-flow (adding,
+// editing, confirming) the check is skipped so indices stay stable; the
+// change is picked up once the flow ends.
+func (m *model) pollConfig(now time.Time) {
+	if now.Sub(m.lastPoll) < reloadPoll {
+		return
+	}
+	m.lastPoll = now
+	switch m.state {
+	case viewDashboard, viewDetail, viewPlanner:
+	default:
+		return
+	}
+
+	stamp := store.CurrentStamp()
+	if stamp.Equal(m.cfgStamp) {
+		return
+	}
+	m.cfgStamp = stamp
+
+	cfg, err := store.Load()
+	if err != nil {
+		m.loadErr = err.Error()
+		return
+	}
+	m.loadErr = ""
+	if slices.EqualFunc(cfg.Boards, m.base.Boards, store.Board.Equal) {
+		m.base.Active = cfg.Active
+		return
+	}
+	m.reload(cfg)
+	m.flash("CONFIG RELOADED")
+}
+
+// reload adopts cfg, keeping the cursor on the same clock where possible:
+// first an identical entry, then one with the same label. Undo history and
+// the planner's exclusions are of the old lists, so they are dropped: undo
+// would otherwise write back the old list over the outside edit.
+func (m *model) reload(cfg store.Config) {
+	m.history = history{}
+	m.excluded = map[int]bool{}
+	var selected store.Entry
+	hadSelection := m.cursor >= 0 && m.cursor < len(m.clocks)
+	if hadSelection {
+		selected = m.clocks[m.cursor]
+	}
+	m.adopt(cfg)
+	defer m.followCursor()
+	if !hadSelection {
+		return
+	}
+	for i, e := range m.clocks {
+		if e == selected {
+			m.cursor = i
+			return
+		}
+	}
+	for i, e := range m.clocks {
+		if e.Label == selected.Label {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// flash shows a short-lived notice in the masthead.
+func (m *model) flash(msg string) {
+	m.notice = msg
+	m.noticeUntil = m.clock.Now().Add(noticeFor)
+}
@@ -55,36 +55,47 @@ func section(num, title, body string, width int) string {
 }
 
 // card is a fixed-width mini-box used for the grid layout on the dashboard.
-// `title` may contain ANSI styling; callers are responsible for sizing it to
-// fit `width-4` visible cells (we pad only).
-func card(width int, selected bool, title, timeStr, meta string) string {
+// `title`, `timeStr`, `badge` and `meta` may contain ANSI styling; callers are
+// responsible for sizing the title to fit `width-4` visible cells (we pad
+// only). The badge is right-aligned on the time row. A non-empty `dial` is
+// centred between the title and the time. An alert card is ruled in the
+// critical color whether or not it's selected.
+func card(width int, selected, alert bool, title, dial, timeStr, badge, meta string) string {
 	if width < 18 {
 		width = 18
 	}
 	inner := width - 4
 
+	// The selected card is heavy-ruled as well as highlighted, so it still
+	// stands out without color.
 	borderStyle := sBorder
+	b := lipgloss.RoundedBorder()
 	if selected {
 		borderStyle = sAmber
+		b = lipgloss.ThickBorder()
+	}
+	if alert {
+		borderStyle = sCrit
 	}
 
-	top := borderStyle.Render("╭" + strings.Repeat("─", width-2) + "╮")
-	bot := borderStyle.Render("╰" + strings.Repeat("─", width-2) + "╯")
+	top := borderStyle.Render(b.TopLeft + strings.Repeat(b.Top, width-2) + b.TopRight)
+	bot := borderStyle.Render(b.BottomLeft + strings.Repeat(b.Bottom, width-2) + b.BottomRight)
 
 	titleLn := padLeft(title, inner)
-	timeLn := padLeft(sBigDigit.Render(timeStr), inner)
-	metaLn := padLeft(sDim.Render(meta), inner)
+	timeLn := timeStr + padRight(badge, inner-lipgloss.Width(timeStr))
+	metaLn := padLeft(meta, inner)
 
 	row := func(content string) string {
-		return borderStyle.Render("│") + " " + content + " " + borderStyle.Render("│")
-	}
-	return strings.Join([]string{
-		top,
-		row(titleLn),
-		row(timeLn),
-		row(metaLn),
-		bot,
-	}, "\n")
+		return borderStyle.Render(b.Left) + " " + content + " " + borderStyle.Render(b.Right)
+	}
+	lines := []string{top, row(titleLn)}
+	if dial != "" {
+		for _, ln := range strings.Split(dial, "\n") {
+			lead := (inner - lipgloss.Width(ln)) / 2
+			lines = append(lines, row(padLeft(strings.Repeat(" ", lead)+ln, inner)))
+		}
+	}
+	return strings.Join(append(lines, row(timeLn), row(metaLn), bot), "\n")
 }
 
 func truncateVisible(s string, n int) string {
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashRow is one row of cards (or a folded group) in the unscrolled
+// dashboard: its first line, its height and the clocks on it. start is
+// where scrolling stops for it — the section's top border for the first row
+// of a section, top otherwise.
+type dashRow struct {
+	start, top, height int
+	clocks             []int
+}
+
+// bodyHeight is how many lines the view leaves between masthead and footer.
+func (m model) bodyHeight() int {
+	return m.height - lipgloss.Height(m.renderMasthead()) - lipgloss.Height(m.renderFooter())
+}
+
+// cursorRow is the index of the row holding the cursor, or -1.
+func (m model) cursorRow(rows []dashRow) int {
+	for r, row := range rows {
+		for _, i := range row.clocks {
+			if i == m.cursor {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+// scrollSpan is how many dashboard lines fit in a window of h lines at
+// offset off, after the ▲ / ▼ indicators take theirs.
+func scrollSpan(total, h, off int) int {
+	n := h
+	if off > 0 {
+		n--
+	}
+	if off+n < total {
+		n--
+	}
+	return n
+}
+
+// clampScroll adjusts the scroll offset off so row cur is fully in view.
+// Scrolling down stops at the start of a row rather than mid-card, so no
+// card is shown cut in half under the ▲ line.
+func clampScroll(rows []dashRow, cur, total, h, off int) int {
+	if total <= h {
+		return 0
+	}
+	off = max(0, min(off, total-1))
+	if cur < 0 {
+		return off
+	}
+	row := rows[cur]
+	end := row.top + row.height
+	if cur == len(rows)-1 {
+		end = total // take the closing border along
+	}
+	switch {
+	case cur == 0:
+		return 0
+	case row.start < off:
+		return row.start
+	case end <= off+scrollSpan(total, h, off):
+		return off
+	}
+
+	need := end - (h - 1) // room for ▲
+	if end < total {
+		need-- // and for ▼
+	}
+	for _, r := range rows[:cur+1] {
+		if r.start >= need {
+			return r.start
+		}
+	}
+	return need
+}
+
+// scrollWindow cuts the dashboard down to the body height around the
+// cursor, with "▲ N more" / "▼ N more" counting the clocks out of view.
+func (m model) scrollWindow(lines []string, rows []dashRow) string {
+	h := m.bodyHeight()
+	total := len(lines)
+	if total <= h || h < 3 {
+		return strings.Join(lines, "\n")
+	}
+	off := clampScroll(rows, m.cursorRow(rows), total, h, m.scroll)
+	n := scrollSpan(total, h, off)
+
+	var above, below int
+	for _, row := range rows {
+		switch {
+		case row.top < off:
+			above += len(row.clocks)
+		case row.top+row.height > off+n:
+			below += len(row.clocks)
+		}
+	}
+	var out []string
+	if off > 0 {
+		out = append(out, moreIndicator("▲", above))
+	}
+	out = append(out, lines[off:min(off+n, total)]...)
+	if off+n < total {
+		out = append(out, moreIndicator("▼", below))
+	}
+	return strings.Join(out, "\n")
+}
+
+func moreIndicator(arrow string, n int) string {
+	if n == 0 {
+		return "  " + sDim.Render(arrow)
+	}
+	return "  " + sAmber.Render(fmt.Sprintf("%s %d more", arrow, n))
+}
+
+// followCursor scrolls the dashboard so the cursor's row stays in view.
+func (m *model) followCursor() {
+	if m.state != viewDashboard || len(m.clocks) == 0 || m.height == 0 {
+		return
+	}
+	lines, rows := m.dashboard()
+	m.scroll = clampScroll(rows, m.cursorRow(rows), len(lines), m.bodyHeight(), m.scroll)
+}
+
+// page moves the cursor a screenful of rows up or down.
+func (m *model) page(dir string) {
+	lines, rows := m.dashboard()
+	cur := m.cursorRow(rows)
+	if cur < 0 {
+		return
+	}
+	h := m.bodyHeight()
+	n := max(1, scrollSpan(len(lines), h, m.scroll)/rows[cur].height)
+	for range n {
+		t := m.neighbour(dir)
+		if t < 0 {
+			break
+		}
+		m.cursor = t
+	}
+}
+
+// jump moves the cursor to the first or last clock on the dashboard.
+func (m *model) jump(last bool) {
+	rows := m.stopRows()
+	if len(rows) == 0 {
+		return
+	}
+	if !last {
+		m.cursor = rows[0][0][0]
+		return
+	}
+	g := rows[len(rows)-1]
+	row := g[len(g)-1]
+	m.cursor = row[len(row)-1]
+}
@@ -1,55 +1,316 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
-// Phosphor-CRT telemetry palette — shared across the Atlas TUI suite.
+	"github.com/fezcode/atlas.clock/pkg/store"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a named palette. Every s* style is built from the active theme
+// by applyTheme; the fields are hex colors ("#FFB000") or ANSI color
+// numbers ("214"). Themes color the foreground only and draw on the
+// terminal's own background, so each suits either dark or light terminals.
+type Theme struct {
+	Name   string         `json:"name"`
+	Chrome lipgloss.Color `json:"chrome"` // borders and rules
+	Dim    lipgloss.Color `json:"dim"`    // labels and secondary text
+	Text   lipgloss.Color `json:"text"`
+	Accent lipgloss.Color `json:"accent"` // titles, keys, selection, digits
+	Hot    lipgloss.Color `json:"hot"`    // twilight, highlights
+	Alert  lipgloss.Color `json:"alert"`  // errors and REC
+	Value  lipgloss.Color `json:"value"`
+	Good   lipgloss.Color `json:"good"`
+	Paper  lipgloss.Color `json:"paper"` // bright foreground
+
+	// Hand-picked stand-ins for the signal colors on 256- and 16-color
+	// terminals; see signal. A user theme inherits them from its base for
+	// the colors it doesn't change.
+	accentBasic, alertBasic, goodBasic basic
+}
+
+// basic is a color's ANSI 256 and ANSI 16 numbers.
+type basic struct{ ansi256, ansi string }
+
+// Themes built into the binary; the first is the default.
+var builtinThemes = []Theme{
+	{
+		// Phosphor-CRT telemetry palette — shared across the Atlas TUI suite.
+		Name: "amber", Chrome: "#3A3226", Dim: "#7A6A4A",
+		Text: "#D9C79C", Accent: "#FFB000", Hot: "#FF7A00", Alert: "#FF3D4A",
+		Value: "#7DE3FF", Good: "#84F5A3", Paper: "#F5E6D3",
+		accentBasic: basic{"214", "11"}, alertBasic: basic{"203", "9"}, goodBasic: basic{"120", "10"},
+	},
+	{
+		// P1 green phosphor.
+		Name: "green", Chrome: "#1F3A24", Dim: "#3F7A4A",
+		Text: "#9FE0A8", Accent: "#33FF66", Hot: "#B4FF3D", Alert: "#FF4D4D",
+		Value: "#7DFFC8", Good: "#C8FFB0", Paper: "#E0FFE6",
+		accentBasic: basic{"83", "10"}, alertBasic: basic{"203", "9"}, goodBasic: basic{"193", "2"},
+	},
+	{
+		// IBM 3279 blue.
+		Name: "ibm", Chrome: "#1E2F4F", Dim: "#5A74A0",
+		Text: "#B8C8E8", Accent: "#5FA8FF", Hot: "#FFD75F", Alert: "#FF5F5F",
+		Value: "#7DE3FF", Good: "#87F5A3", Paper: "#EAF2FF",
+		accentBasic: basic{"75", "12"}, alertBasic: basic{"203", "9"}, goodBasic: basic{"120", "10"},
+	},
+	{
+		// Dark ink; light terminal backgrounds only.
+		Name: "paper", Chrome: "#B8A88A", Dim: "#8A7A5A",
+		Text: "#3A3226", Accent: "#B35C00", Hot: "#C2410C", Alert: "#C8102E",
+		Value: "#005F87", Good: "#1A7F37", Paper: "#1A1410",
+		// The dark ANSI colors, which read on a light background.
+		accentBasic: basic{"130", "3"}, alertBasic: basic{"160", "1"}, goodBasic: basic{"28", "2"},
+	},
+	{
+		Name: "contrast", Chrome: "#FFFFFF", Dim: "#C0C0C0",
+		Text: "#FFFFFF", Accent: "#FFFF00", Hot: "#FF8700", Alert: "#FF0000",
+		Value: "#00FFFF", Good: "#00FF00", Paper: "#FFFFFF",
+		accentBasic: basic{"226", "11"}, alertBasic: basic{"196", "9"}, goodBasic: basic{"46", "10"},
+	},
+}
+
+// The active palette; see applyTheme.
 var (
-	ColBG       = lipgloss.Color("#000000")
-	ColChrome   = lipgloss.Color("#3A3226")
-	ColDim      = lipgloss.Color("#7A6A4A")
-	ColText     = lipgloss.Color("#D9C79C")
-	ColAmber    = lipgloss.Color("#FFB000")
-	ColAmberHot = lipgloss.Color("#FF7A00")
-	ColRed      = lipgloss.Color("#FF3D4A")
-	ColCyan     = lipgloss.Color("#7DE3FF")
-	ColGreen    = lipgloss.Color("#84F5A3")
-	ColPaper    = lipgloss.Color("#F5E6D3")
+	ColChrome   lipgloss.TerminalColor
+	ColDim      lipgloss.TerminalColor
+	ColText     lipgloss.TerminalColor
+	ColAmber    lipgloss.TerminalColor
+	ColAmberHot lipgloss.TerminalColor
+	ColRed      lipgloss.TerminalColor
+	ColCyan     lipgloss.TerminalColor
+	ColGreen    lipgloss.TerminalColor
+	ColPaper    lipgloss.TerminalColor
 )
 
 var (
-	sBorder       = lipgloss.NewStyle().Foreground(ColChrome)
-	sLabel        = lipgloss.NewStyle().Foreground(ColDim)
-	sText         = lipgloss.NewStyle().Foreground(ColText)
-	sValue        = lipgloss.NewStyle().Foreground(ColCyan).Bold(true)
-	sPaper        = lipgloss.NewStyle().Foreground(ColPaper).Bold(true)
-	sAmber        = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sHot          = lipgloss.NewStyle().Foreground(ColAmberHot).Bold(true)
-	sCrit         = lipgloss.NewStyle().Foreground(ColRed).Bold(true)
-	sGood         = lipgloss.NewStyle().Foreground(ColGreen).Bold(true)
-	sDim          = lipgloss.NewStyle().Foreground(ColDim)
-	sRec          = lipgloss.NewStyle().Foreground(ColRed).Bold(true)
-	sSectionTitle = lipgloss.NewStyle().Foreground(ColPaper).Bold(true)
-	sSectionKey   = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-
-	sFooterKey  = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sFooterText = lipgloss.NewStyle().Foreground(ColDim)
-
-	sMastTitle  = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sMastClock  = lipgloss.NewStyle().Foreground(ColPaper).Bold(true)
-	sCursor     = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sBigDigit   = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sPromptMark = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	sMs         = lipgloss.NewStyle().Foreground(ColDim)
+	sBorder       lipgloss.Style
+	sLabel        lipgloss.Style
+	sText         lipgloss.Style
+	sValue        lipgloss.Style
+	sPaper        lipgloss.Style
+	sAmber        lipgloss.Style
+	sHot          lipgloss.Style
+	sCrit         lipgloss.Style
+	sGood         lipgloss.Style
+	sDim          lipgloss.Style
+	sRec          lipgloss.Style
+	sSectionTitle lipgloss.Style
+	sSectionKey   lipgloss.Style
+
+	sFooterKey  lipgloss.Style
+	sFooterText lipgloss.Style
+
+	sMastTitle  lipgloss.Style
+	sMastClock  lipgloss.Style
+	sCursor     lipgloss.Style
+	sBigDigit   lipgloss.Style
+	sPromptMark lipgloss.Style
+	sMs         lipgloss.Style
 )
 
-// daynightStyle colors the day/night glyph based on the local hour.
+func init() { applyTheme(builtinThemes[0]) }
+
+// applyTheme makes t the active palette and rebuilds every style from it.
+// Styles already copied into bubbles components are refreshed separately;
+// see model.restyle.
+func applyTheme(t Theme) {
+	ColChrome, ColDim, ColText = shade(t.Chrome), shade(t.Dim), hue(t.Text)
+	ColAmber, ColAmberHot, ColRed = signal(t.Accent, t.accentBasic), hue(t.Hot), signal(t.Alert, t.alertBasic)
+	ColCyan, ColGreen, ColPaper = hue(t.Value), signal(t.Good, t.goodBasic), hue(t.Paper)
+
+	fg := func(c lipgloss.TerminalColor) lipgloss.Style { return lipgloss.NewStyle().Foreground(c) }
+	sBorder = fg(ColChrome)
+	sLabel = fg(ColDim)
+	sText = fg(ColText)
+	sValue = fg(ColCyan).Bold(true)
+	sPaper = fg(ColPaper).Bold(true)
+	sAmber = fg(ColAmber).Bold(true)
+	sHot = fg(ColAmberHot).Bold(true)
+	sCrit = fg(ColRed).Bold(true)
+	sGood = fg(ColGreen).Bold(true)
+	sDim = fg(ColDim)
+	sRec = fg(ColRed).Bold(true)
+	sSectionTitle = fg(ColPaper).Bold(true)
+	sSectionKey = fg(ColAmber).Bold(true)
+
+	sFooterKey = fg(ColAmber).Bold(true)
+	sFooterText = fg(ColDim)
+
+	sMastTitle = fg(ColAmber).Bold(true)
+	sMastClock = fg(ColPaper).Bold(true)
+	sCursor = fg(ColAmber).Bold(true)
+	sBigDigit = fg(ColAmber).Bold(true)
+	sPromptMark = fg(ColAmber).Bold(true)
+	sMs = fg(ColDim)
+}
+
+// colorPattern matches what a theme file may use as a color: #RGB,
+// #RRGGBB or an ANSI color number.
+var colorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3}|#[0-9A-Fa-f]{6}|[0-9]{1,3})$`)
+
+// ThemeDir is where user themes live: a themes directory next to the
+// config file.
+func ThemeDir() (string, error) {
+	path, err := store.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "themes"), nil
+}
+
+// LoadThemes returns the built-in themes followed by the user's, in file
+// name order. A user theme named like a built-in replaces it. Each *.json file
+// holds one Theme; "base" names the theme its missing colors come from
+// (amber when unset), and the name defaults to the file name. Files that
+// fail to load are skipped and reported in the error, which never hides
+// the themes that did load.
+func LoadThemes() ([]Theme, error) {
+	themes := slices.Clone(builtinThemes)
+	dir, err := ThemeDir()
+	if err != nil {
+		return themes, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return themes, err
+	}
+	slices.Sort(paths)
+
+	var errs []error
+	for _, path := range paths {
+		t, err := loadTheme(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i := findTheme(themes, t.Name); i >= 0 && i < len(builtinThemes) {
+			themes[i] = t
+		} else if i < 0 {
+			themes = append(themes, t)
+		}
+	}
+	return themes, errors.Join(errs...)
+}
+
+func loadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", filepath.Base(path), err)
+	}
+	var file struct {
+		Theme
+		Base string `json:"base"`
+		// BG is only read to reject it: a background can't be painted
+		// reliably behind every cell, so it would be silently ignored.
+		BG string `json:"bg"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", filepath.Base(path), err)
+	}
+	if file.BG != "" {
+		return Theme{}, fmt.Errorf("theme %s: bg is not supported; themes draw on the terminal's background", filepath.Base(path))
+	}
+
+	base := builtinThemes[0]
+	if file.Base != "" {
+		i := findTheme(builtinThemes, file.Base)
+		if i < 0 {
+			return Theme{}, fmt.Errorf("theme %s: unknown base theme %q", filepath.Base(path), file.Base)
+		}
+		base = builtinThemes[i]
+	}
+	t := file.Theme
+	t.accentBasic, t.alertBasic, t.goodBasic = base.accentBasic, base.alertBasic, base.goodBasic
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	for _, c := range []struct {
+		field    string
+		col      *lipgloss.Color
+		fallback lipgloss.Color
+		basic    *basic
+	}{
+		{"chrome", &t.Chrome, base.Chrome, nil},
+		{"dim", &t.Dim, base.Dim, nil},
+		{"text", &t.Text, base.Text, nil},
+		{"accent", &t.Accent, base.Accent, &t.accentBasic},
+		{"hot", &t.Hot, base.Hot, nil},
+		{"alert", &t.Alert, base.Alert, &t.alertBasic},
+		{"value", &t.Value, base.Value, nil},
+		{"good", &t.Good, base.Good, &t.goodBasic},
+		{"paper", &t.Paper, base.Paper, nil},
+	} {
+		if *c.col == "" {
+			*c.col = c.fallback
+			continue
+		}
+		if c.basic != nil {
+			// The base's stand-ins were picked for the base's color.
+			*c.basic = basic{}
+		}
+		if !colorPattern.MatchString(string(*c.col)) {
+			return Theme{}, fmt.Errorf("theme %s: %s: %q is not a #RRGGBB or ANSI color", filepath.Base(path), c.field, string(*c.col))
+		}
+	}
+	return t, nil
+}
+
+// findTheme returns the index of the theme called name (case-insensitive),
+// or -1.
+func findTheme(themes []Theme, name string) int {
+	return slices.IndexFunc(themes, func(t Theme) bool {
+		return strings.EqualFold(t.Name, name)
+	})
+}
+
+// themeNames lists the themes for help and error messages.
+func themeNames(themes []Theme) string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// daynightStyle colors the day/night glyph based on the local hour. Without
+// color, twilight gets a half-disc, as its moon is hard to tell from night's.
 func daynightStyle(hour int) (string, lipgloss.Style) {
-	switch {
-	case hour >= 6 && hour < 18:
-		return "☀", sAmber
-	case hour >= 18 && hour < 22, hour >= 4 && hour < 6:
-		return "☽", sHot
+	phase := store.PhaseAt(hour)
+	switch phase {
+	case store.PhaseDay:
+		return phase.Glyph(), sAmber
+	case store.PhaseTwilight:
+		if monochrome() {
+			return "◐", sHot
+		}
+		return phase.Glyph(), sHot
+	default:
+		return phase.Glyph(), sDim
+	}
+}
+
+// availabilityStyle colors the AVAILABLE / AFTER HOURS / … badge.
+func availabilityStyle(a store.Availability) lipgloss.Style {
+	switch a {
+	case store.Available:
+		return sGood
+	case store.OnCall:
+		return sAmber
+	case store.OutOfOffice:
+		return sCrit
+	case store.Weekend:
+		return sHot
 	default:
-		return "☾", sDim
+		return sDim
 	}
 }
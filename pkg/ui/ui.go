@@ -24,11 +24,16 @@ const (
 	viewZonePicker
 	viewConfirmAdd
 	viewConfirmDelete
+	viewPlanner
 )
 
 // --- messages ---------------------------------------------------------------
 
-type tickMsg time.Time
+// tickMsg is a frame tick; gen is the tick loop that scheduled it.
+type tickMsg struct {
+	at  time.Time
+	gen int
+}
 
 // --- zone item --------------------------------------------------------------
 
@@ -44,36 +49,86 @@ type model struct {
 	version string
 	state   viewState
 
+	// clocks are the shown board's; the other boards live in base.
+	board  string
 	clocks []store.Entry
 	cursor int
+	// base is the config as last read from or written to disk; saves merge
+	// against it so concurrent instances don't clobber each other.
+	base store.Config
 
 	textInput textinput.Model
 	zoneList  list.Model
 	newEntry  store.Entry
+	// editing routes the label → zone → confirm flow to replace the clock at
+	// editIndex instead of appending a new one.
+	editing   bool
+	editIndex int
+
+	history history
+
+	// Persistence failures, shown in the masthead until resolved. A load
+	// error sticks for the session; a save error clears on the next success.
+	loadErr string
+	saveErr string
+	// badZones are the clocks, on any board, whose location didn't resolve
+	// when the config was last loaded or saved.
+	badZones []store.Problem
+
+	// Live reload: the config file as last seen, when it was last polled,
+	// and the notice flashed in the masthead afterwards.
+	cfgStamp    store.Stamp
+	lastPoll    time.Time
+	notice      string
+	noticeUntil time.Time
+
+	// shift is the time-travel offset applied to every card; zero is live.
+	shift time.Duration
+
+	// collapsed holds the dashboard groups folded away with c.
+	collapsed map[string]bool
+	// layout is how the dashboard draws its clocks; see listView.
+	layout layout
+	// themes are the palettes t cycles through; theme is the active one.
+	themes   []Theme
+	theme    int
+	themeErr string
+	// scroll is the first dashboard line shown when the grid is taller
+	// than the terminal; see followCursor.
+	scroll int
+
+	// Meeting planner: selected hour column and clocks left out of the overlap.
+	slot     int
+	excluded map[int]bool
 
 	width, height int
 	blink         bool
-	frame         int
-	started       time.Time
+	// clock is the time source for everything shown; see Config.Clock.
+	clock   store.Clock
+	started time.Time
+	// tickGen is the live tick loop; ticks from older loops are dropped.
+	// paused stops the loop while the terminal is out of focus.
+	tickGen int
+	paused  bool
 }
 
 // Config bundles launch parameters.
 type Config struct {
 	Version string
+	// Theme names the starting theme; empty means the first one.
+	Theme string
+	// Clock is the time source; nil means the system clock.
+	Clock store.Clock
 }
 
-func newModel(cfg Config) model {
-	ti := textinput.New()
-	ti.Placeholder = "label (e.g. Tokyo Office)"
-	ti.CharLimit = 64
-	ti.Prompt = ""
-	ti.TextStyle = sPaper
-	ti.PlaceholderStyle = sDim
+// restyle applies the selected theme and refreshes the styles copied into
+// the bubbles components, which don't see later changes to the s* vars.
+func (m *model) restyle() {
+	applyTheme(m.themes[m.theme])
+
+	m.textInput.TextStyle = sPaper
+	m.textInput.PlaceholderStyle = sDim
 
-	items := make([]list.Item, len(store.IANAZones))
-	for i, tz := range store.IANAZones {
-		items[i] = zoneItem(tz)
-	}
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
 		Foreground(ColAmber).
@@ -86,33 +141,91 @@ func newModel(cfg Config) model {
 	delegate.Styles.NormalDesc = delegate.Styles.NormalDesc.Foreground(ColDim)
 	delegate.Styles.DimmedTitle = delegate.Styles.DimmedTitle.Foreground(ColDim)
 	delegate.Styles.DimmedDesc = delegate.Styles.DimmedDesc.Foreground(ColDim)
+	m.zoneList.SetDelegate(delegate)
+	m.zoneList.Styles.Title = lipgloss.NewStyle().Foreground(ColPaper).Bold(true)
+	m.zoneList.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
+	m.zoneList.FilterInput.TextStyle = sPaper
+}
 
-	zl := list.New(items, delegate, 0, 0)
+// cycleTheme switches to the next theme.
+func (m *model) cycleTheme() {
+	m.theme = (m.theme + 1) % len(m.themes)
+	m.restyle()
+	m.flash("THEME " + strings.ToUpper(m.themes[m.theme].Name))
+}
+
+func newModel(cfg Config) model {
+	ti := textinput.New()
+	ti.Placeholder = "label (e.g. Tokyo Office)"
+	ti.CharLimit = 64
+	ti.Prompt = ""
+
+	items := make([]list.Item, len(store.IANAZones))
+	for i, tz := range store.IANAZones {
+		items[i] = zoneItem(tz)
+	}
+	zl := list.New(items, list.NewDefaultDelegate(), 0, 0)
 	zl.Title = "SELECT TIMEZONE — type to filter"
 	zl.SetShowStatusBar(false)
 	zl.SetFilteringEnabled(true)
-	zl.Styles.Title = lipgloss.NewStyle().Foreground(ColPaper).Bold(true)
-	zl.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(ColAmber).Bold(true)
-	zl.FilterInput.TextStyle = sPaper
-
-	cfgData := store.Load()
-	return model{
-		version: cfg.Version,
-		state:   viewDashboard,
-		clocks:  cfgData.Clocks,
+
+	themes, themeErr := LoadThemes()
+	// Stamped before loading: a write in between is then seen as news and
+	// reloaded, rather than missed.
+	stamp := store.CurrentStamp()
+	cfgData, err := store.Load()
+	m := model{
+		version:   cfg.Version,
+		state:     viewDashboard,
+		base:      cfgData,
 		textInput: ti,
-		zoneList: zl,
-		started:  time.Now(),
+		zoneList:  zl,
+		themes:    themes,
+		theme:     max(0, findTheme(themes, cfg.Theme)),
+		clock:     cfg.Clock,
+		cfgStamp:  stamp,
+	}
+	if err != nil {
+		m.loadErr = err.Error()
+	}
+	if m.clock == nil {
+		m.clock = store.SystemClock{}
 	}
+	m.started = m.clock.Now()
+	if themeErr != nil {
+		m.themeErr = themeErr.Error()
+	}
+	m.restyle()
+	m.board = cfgData.Selected()
+	m.clocks = m.boardClocks(m.board)
+	m.badZones = unknownZones(cfgData)
+	return m
 }
 
 // --- tea.Model --------------------------------------------------------------
 
-func (m model) Init() tea.Cmd { return tick() }
+func (m model) Init() tea.Cmd { return m.tick() }
+
+// tick schedules the next frame. The detail view ticks at 20 Hz so its
+// milliseconds feel live; everything else shows whole seconds, so it ticks
+// once a second, on the wall-clock second so the digits turn over on time.
+func (m model) tick() tea.Cmd {
+	gen := m.tickGen
+	msg := func(t time.Time) tea.Msg { return tickMsg{at: t, gen: gen} }
+	if m.state == viewDetail {
+		return tea.Tick(50*time.Millisecond, msg)
+	}
+	return tea.Every(time.Second, msg)
+}
 
-func tick() tea.Cmd {
-	// Tick at 20 Hz so milliseconds in detail view feel live without wasting CPU.
-	return tea.Tick(50*time.Millisecond, func(t time.Time) tea.Msg { return tickMsg(t) })
+// retick starts a new tick loop, orphaning the running one: for a change of
+// rate, or to resume after a pause.
+func (m *model) retick() tea.Cmd {
+	m.tickGen++
+	if m.paused {
+		return nil
+	}
+	return m.tick()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -129,15 +242,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			listH = 6
 		}
 		m.zoneList.SetSize(listW, listH)
+		m.followCursor()
 		return m, tea.ClearScreen
 
 	case tickMsg:
-		m.frame++
-		m.blink = m.frame%10 == 0
-		return m, tick()
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		m.blink = msg.at.Second()%2 == 0
+		m.pollConfig(msg.at)
+		return m, m.tick()
+
+	// Nothing moves while another window has focus; the masthead says so
+	// rather than leaving a stale time up as if it were live.
+	case tea.BlurMsg:
+		m.paused = true
+		m.tickGen++
+		return m, nil
+	case tea.FocusMsg:
+		m.paused = false
+		return m, m.retick()
 
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		detail := m.state == viewDetail
+		next, cmd := m.handleKey(msg)
+		if nm, ok := next.(model); ok && (nm.state == viewDetail) != detail {
+			tick := nm.retick()
+			return nm, tea.Batch(cmd, tick)
+		}
+		return next, cmd
 	}
 	return m, nil
 }
@@ -154,31 +287,28 @@ func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.keyConfirmDelete(msg)
 	case viewDetail:
 		return m.keyDetail(msg)
+	case viewPlanner:
+		return m.keyPlanner(msg)
 	default:
 		return m.keyDashboard(msg)
 	}
 }
 
+// navDir maps navigation and reorder keys to a grid direction.
+var navDir = map[string]string{
+	"up": "up", "k": "up", "K": "up", "shift+up": "up",
+	"down": "down", "j": "down", "J": "down", "shift+down": "down",
+	"left": "left", "h": "left", "H": "left", "shift+left": "left",
+	"right": "right", "l": "right", "L": "right", "shift+right": "right",
+}
+
 func (m model) keyDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	gridCols := m.gridCols()
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
-	case "up", "k":
-		if m.cursor >= gridCols {
-			m.cursor -= gridCols
-		}
-	case "down", "j":
-		if m.cursor+gridCols < len(m.clocks) {
-			m.cursor += gridCols
-		}
-	case "left", "h":
-		if m.cursor > 0 {
-			m.cursor--
-		}
-	case "right", "l":
-		if m.cursor < len(m.clocks)-1 {
-			m.cursor++
+	case "up", "k", "down", "j", "left", "h", "right", "l":
+		if t := m.neighbour(navDir[msg.String()]); t >= 0 {
+			m.cursor = t
 		}
 	case "enter":
 		if len(m.clocks) > 0 {
@@ -186,59 +316,107 @@ func (m model) keyDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "a":
 		m.state = viewLabelInput
+		m.editing = false
+		// New clocks join the selected clock's group.
+		m.newEntry = store.Entry{}
+		if m.cursor < len(m.clocks) {
+			m.newEntry.Group = m.clocks[m.cursor].Group
+		}
 		m.textInput.Reset()
 		m.textInput.Focus()
 		return m, textinput.Blink
+	case "e":
+		if len(m.clocks) == 0 {
+			break
+		}
+		m.state = viewLabelInput
+		m.editing = true
+		m.editIndex = m.cursor
+		m.newEntry = m.clocks[m.cursor]
+		m.textInput.Reset()
+		m.textInput.SetValue(m.newEntry.Label)
+		m.textInput.CursorEnd()
+		m.textInput.Focus()
+		return m, textinput.Blink
 	case "d":
 		if len(m.clocks) > 0 {
 			m.state = viewConfirmDelete
 		}
-	case "K", "shift+up":
-		if m.cursor >= gridCols {
-			m.clocks[m.cursor], m.clocks[m.cursor-gridCols] =
-				m.clocks[m.cursor-gridCols], m.clocks[m.cursor]
-			m.cursor -= gridCols
-			_ = store.Save(store.Config{Clocks: m.clocks})
-		}
-	case "J", "shift+down":
-		if m.cursor+gridCols < len(m.clocks) {
-			m.clocks[m.cursor], m.clocks[m.cursor+gridCols] =
-				m.clocks[m.cursor+gridCols], m.clocks[m.cursor]
-			m.cursor += gridCols
-			_ = store.Save(store.Config{Clocks: m.clocks})
-		}
-	case "H", "shift+left":
-		if m.cursor > 0 {
-			m.clocks[m.cursor], m.clocks[m.cursor-1] =
-				m.clocks[m.cursor-1], m.clocks[m.cursor]
-			m.cursor--
-			_ = store.Save(store.Config{Clocks: m.clocks})
-		}
-	case "L", "shift+right":
-		if m.cursor < len(m.clocks)-1 {
-			m.clocks[m.cursor], m.clocks[m.cursor+1] =
-				m.clocks[m.cursor+1], m.clocks[m.cursor]
-			m.cursor++
-			_ = store.Save(store.Config{Clocks: m.clocks})
+	case "[":
+		m.shift -= scrubStep
+	case "]":
+		m.shift += scrubStep
+	case "{":
+		m.shift -= scrubBigStep
+	case "}":
+		m.shift += scrubBigStep
+	case "n":
+		m.shift = 0
+	case "c":
+		m.toggleCollapse()
+	case "v":
+		m.layout = (m.layout + 1) % layoutCount
+	case "t":
+		m.cycleTheme()
+	case "tab":
+		m.switchBoard(m.boardIndex() + 1)
+	case "shift+tab":
+		m.switchBoard(m.boardIndex() - 1)
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(msg.String()[0] - '1'); i < len(m.boardNames()) {
+			m.switchBoard(i)
+		}
+	case "u":
+		m.undo()
+	case "ctrl+r":
+		m.redo()
+	case "p":
+		if len(m.clocks) > 0 {
+			m.state = viewPlanner
+			m.slot = 0
+			m.excluded = map[int]bool{}
 		}
+	case "K", "shift+up", "J", "shift+down", "H", "shift+left", "L", "shift+right":
+		m.reorder(navDir[msg.String()])
+	case "pgup":
+		m.page("up")
+	case "pgdown":
+		m.page("down")
+	case "home":
+		m.jump(false)
+	case "end":
+		m.jump(true)
 	}
+	m.followCursor()
 	return m, nil
 }
 
+// Time-travel scrubber steps for [ ] and { }.
+const (
+	scrubStep    = 15 * time.Minute
+	scrubBigStep = time.Hour
+)
+
+// now is the instant the cards display: wall-clock time plus the scrub offset.
+func (m model) now() time.Time {
+	return m.clock.Now().Add(m.shift)
+}
+
 func (m model) keyDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
 	case "esc", "enter":
 		m.state = viewDashboard
-	case "left", "h":
-		if m.cursor > 0 {
-			m.cursor--
-		}
-	case "right", "l":
-		if m.cursor < len(m.clocks)-1 {
-			m.cursor++
+	case "n":
+		m.shift = 0
+	case "left", "h", "right", "l":
+		if t := m.neighbour(navDir[msg.String()]); t >= 0 {
+			m.cursor = t
 		}
+	case "e":
+		// Offered on an unknown zone, so the fix is one key away.
+		return m.keyDashboard(msg)
 	}
 	return m, nil
 }
@@ -257,6 +435,13 @@ func (m model) keyLabelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.newEntry.Label = val
 		m.state = viewZonePicker
+		if m.editing {
+			loc := m.newEntry.Location
+			if _, err := store.LoadZone(loc); err != nil {
+				loc = store.SuggestZone(loc)
+			}
+			m.selectZone(loc)
+		}
 		return m, nil
 	}
 	var cmd tea.Cmd
@@ -264,6 +449,19 @@ func (m model) keyLabelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// selectZone clears any filter and moves the picker onto the named zone, so
+// editing a clock starts from its current location — or, for an unknown
+// one, from the suggested fix.
+func (m *model) selectZone(name string) {
+	m.zoneList.ResetFilter()
+	for i, tz := range store.IANAZones {
+		if tz == name {
+			m.zoneList.Select(i)
+			return
+		}
+	}
+}
+
 func (m model) keyZonePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
@@ -294,8 +492,14 @@ func (m model) keyZonePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m model) keyConfirmAdd(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y", "enter":
-		m.clocks = append(m.clocks, m.newEntry)
-		_ = store.Save(store.Config{Clocks: m.clocks})
+		if m.editing && m.editIndex < len(m.clocks) {
+			m.record("EDIT " + m.clocks[m.editIndex].Label)
+			m.clocks[m.editIndex] = m.newEntry
+		} else {
+			m.record("ADD " + m.newEntry.Label)
+			m.clocks = append(m.clocks, m.newEntry)
+		}
+		m.save()
 		m.state = viewDashboard
 		m.textInput.Reset()
 	case "n", "N", "esc", "ctrl+c":
@@ -309,11 +513,12 @@ func (m model) keyConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y", "enter":
 		if m.cursor >= 0 && m.cursor < len(m.clocks) {
+			m.record("DELETE " + m.clocks[m.cursor].Label)
 			m.clocks = append(m.clocks[:m.cursor], m.clocks[m.cursor+1:]...)
 			if m.cursor >= len(m.clocks) && m.cursor > 0 {
 				m.cursor--
 			}
-			_ = store.Save(store.Config{Clocks: m.clocks})
+			m.save()
 		}
 		m.state = viewDashboard
 	case "n", "N", "esc", "ctrl+c":
@@ -328,8 +533,8 @@ func (m model) View() string {
 	if m.width == 0 || m.height == 0 {
 		return ""
 	}
-	if m.width < 64 {
-		return sCrit.Render(" terminal too narrow — resize to ≥ 64 columns ")
+	if m.width < minWidth {
+		return sCrit.Render(fmt.Sprintf(" too narrow — need ≥ %d columns ", minWidth))
 	}
 
 	var body string
@@ -346,11 +551,19 @@ func (m model) View() string {
 		body = m.renderConfirmAdd()
 	case viewConfirmDelete:
 		body = m.renderConfirmDelete()
+	case viewPlanner:
+		body = m.renderPlanner()
 	}
 
 	full := m.renderMasthead() + "\n" + body + "\n" + m.renderFooter()
 
 	lines := strings.Split(full, "\n")
+	clip := lipgloss.NewStyle().MaxWidth(m.width)
+	for i, ln := range lines {
+		if lipgloss.Width(ln) > m.width {
+			lines[i] = clip.Render(ln)
+		}
+	}
 	if len(lines) < m.height {
 		blank := strings.Repeat(" ", m.width)
 		for len(lines) < m.height {
@@ -372,15 +585,29 @@ func (m model) renderMasthead() string {
 		sDim.Render("  ·  ") +
 		sMastTitle.Render("C L O C K")
 
-	local := sMastClock.Render(time.Now().Format("15:04:05"))
+	now := m.clock.Now()
+	local := sMastClock.Render(now.Format("15:04:05"))
 	var rec string
-	if m.blink {
+	switch {
+	case m.paused:
+		rec = sHot.Render("‖ PAUSED")
+	case m.blink:
 		rec = sRec.Render("● SYNC")
-	} else {
-		rec = sDim.Render("● SYNC")
+	default:
+		rec = sDim.Render("○ SYNC")
 	}
 	ver := sDim.Render("v" + m.version)
 	right := horiz(local, rec, ver)
+	if m.notice != "" && now.Before(m.noticeUntil) {
+		right = horiz(sGood.Render("⟳ "+m.notice), local, rec, ver)
+	}
+	// Narrow terminals keep the title and the local clock.
+	if lipgloss.Width(title)+lipgloss.Width(right)+3 > w {
+		right = local
+	}
+	if lipgloss.Width(title)+lipgloss.Width(right)+3 > w {
+		title = sMastTitle.Render("ATLAS·CLOCK")
+	}
 
 	titleW := lipgloss.Width(title)
 	rightW := lipgloss.Width(right)
@@ -390,27 +617,62 @@ func (m model) renderMasthead() string {
 	}
 	line1 := "  " + title + strings.Repeat(" ", pad) + right
 
-	_, off := time.Now().Zone()
-	zoneName, _ := time.Now().Zone()
+	zoneName, off := now.Zone()
+	board := sDim.Render("BOARD ") + sAmber.Render(strings.ToUpper(m.board))
+	if n := len(m.boardNames()); n > 1 {
+		board += sDim.Render(fmt.Sprintf(" %d/%d", m.boardIndex()+1, n))
+	}
+	clocks := sDim.Render("CLOCKS ") + sValue.Render(fmt.Sprintf("%d", len(m.clocks)))
 	meta := horiz(
-		sDim.Render("CLOCKS ")+sValue.Render(fmt.Sprintf("%d", len(m.clocks))),
-		sDim.Render("LOCAL ")+sValue.Render(zoneName+" "+formatOffset(off)),
-		sDim.Render("DATE ")+sValue.Render(time.Now().Format("Mon 02 Jan 2006")),
+		board,
+		clocks,
+		sDim.Render("LOCAL ")+sValue.Render(zoneName+" "+store.FormatOffset(off)),
+		sDim.Render("DATE ")+sValue.Render(now.Format("Mon 02 Jan 2006")),
 	)
 	line2 := "  " + meta
 	if lipgloss.Width(line2) > w {
-		line2 = "  " + horiz(
-			sDim.Render("CLOCKS ")+sValue.Render(fmt.Sprintf("%d", len(m.clocks))),
-			sDim.Render("LOCAL ")+sValue.Render(zoneName),
-		)
+		line2 = "  " + horiz(board, clocks, sDim.Render("LOCAL ")+sValue.Render(zoneName))
+	}
+	if lipgloss.Width(line2) > w {
+		line2 = "  " + horiz(board, clocks)
 	}
 
-	return strings.Join([]string{rule, line1, line2, rule}, "\n")
+	lines := []string{rule, line1, line2}
+	if banner := m.renderStoreBanner(); banner != "" {
+		lines = append(lines, banner)
+	}
+	return strings.Join(append(lines, rule), "\n")
+}
+
+// renderStoreBanner surfaces load/save failures so a curated dashboard is
+// never lost silently.
+func (m model) renderStoreBanner() string {
+	var msgs []string
+	if m.loadErr != "" {
+		msgs = append(msgs, "LOAD FAILED — showing defaults: "+m.loadErr)
+	}
+	if m.themeErr != "" {
+		msgs = append(msgs, "THEMES SKIPPED: "+m.themeErr)
+	}
+	if m.saveErr != "" {
+		msgs = append(msgs, "SAVE FAILED — changes are not persisted: "+m.saveErr)
+	}
+	if msg := m.zoneWarning(); msg != "" {
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return ""
+	}
+	wrapped := sCrit.Width(m.width - 4).Render("⚠ " + strings.Join(msgs, "  ·  "))
+	return "  " + strings.ReplaceAll(wrapped, "\n", "\n  ")
 }
 
 // --- Dashboard (§01 grid of clock cards) -----------------------------------
 
 func (m model) gridCols() int {
+	if m.listView() {
+		return 1
+	}
 	cardW := 26
 	gap := 2
 	cols := (m.width - 4 + gap) / (cardW + gap)
@@ -427,6 +689,85 @@ func (m model) renderDashboard() string {
 	if len(m.clocks) == 0 {
 		return section("01", "DASHBOARD", sDim.Render("no clocks — press A to add your first"), m.width)
 	}
+	lines, rows := m.dashboard()
+	return m.scrollWindow(lines, rows)
+}
+
+// dashboard renders the whole dashboard, unscrolled, as lines, along with
+// where each row of cards sits in them.
+func (m model) dashboard() ([]string, []dashRow) {
+	now := m.now()
+	var banner []string
+	if m.shift != 0 {
+		banner = append(banner, m.renderScrubBanner(now), "")
+	}
+
+	var lines []string
+	var rows []dashRow
+	// addSection appends a section whose body starts with lead lines and
+	// continues with the given rows, each holding the clocks listed in
+	// clocks.
+	addSection := func(num, title string, lead []string, body []string, clocks [][]int) {
+		start, top := len(lines), len(lines)+1+len(lead)
+		for i, b := range body {
+			h := lipgloss.Height(b)
+			rows = append(rows, dashRow{start: start, top: top, height: h, clocks: clocks[i]})
+			start = top + h
+			top += h
+		}
+		content := strings.Join(append(lead, body...), "\n")
+		lines = append(lines, strings.Split(section(num, title, content, m.width), "\n")...)
+	}
+
+	if !m.grouped() {
+		all := make([]int, len(m.clocks))
+		for i := range all {
+			all[i] = i
+		}
+		body, clocks := m.renderGrid(all, now)
+		addSection("01", "DASHBOARD", banner, body, clocks)
+		return lines, rows
+	}
+
+	// One section per group; the scrub banner sits above them all.
+	if len(banner) > 0 {
+		lines = append(lines, "  "+banner[0])
+	}
+	for gi, g := range m.groups() {
+		num, title := fmt.Sprintf("%02d", gi+1), strings.ToUpper(g.title())
+		if !m.collapsed[g.name] {
+			body, clocks := m.renderGrid(g.clocks, now)
+			addSection(num, title, nil, body, clocks)
+			continue
+		}
+		style := sDim
+		for _, i := range g.clocks {
+			if i == m.cursor {
+				style = sAmber
+			}
+		}
+		noun := "clocks"
+		if len(g.clocks) == 1 {
+			noun = "clock"
+		}
+		folded := style.Render(fmt.Sprintf("▸ %d %s folded — C to expand", len(g.clocks), noun))
+		addSection(num, title, nil, []string{folded}, [][]int{g.clocks})
+	}
+	return lines, rows
+}
+
+// renderGrid lays out the given clocks as rows of cards, or one line each
+// in the list view, returning each row with the clocks on it.
+func (m model) renderGrid(clocks []int, now time.Time) ([]string, [][]int) {
+	if m.listView() {
+		var rows []string
+		var members [][]int
+		for _, j := range clocks {
+			rows = append(rows, m.renderListLine(j, m.width-4, now))
+			members = append(members, []int{j})
+		}
+		return rows, members
+	}
 
 	cardW := 26
 	gap := 2
@@ -442,53 +783,109 @@ func (m model) renderDashboard() string {
 	}
 
 	var rows []string
-	i := 0
-	for i < len(m.clocks) {
-		end := i + cols
-		if end > len(m.clocks) {
-			end = len(m.clocks)
-		}
+	var members [][]int
+	for i := 0; i < len(clocks); i += cols {
+		row := clocks[i:min(i+cols, len(clocks))]
 		var cards []string
-		innerW := cardW - 4
-		for j := i; j < end; j++ {
-			entry := m.clocks[j]
-			t := entry.Now()
-			dnGlyph, dnStyle := daynightStyle(t.Hour())
-
-			_, off := t.Zone()
-
-			// Compose the title from raw pieces so we can size the label
-			// against the card's inner width without tripping over the glyph's
-			// ANSI escapes.
-			const glyphSlot = 3 // "X  "
-			labelBudget := innerW - glyphSlot
-			if labelBudget < 3 {
-				labelBudget = 3
-			}
-			labelStyle := sPaper
-			if j == m.cursor {
-				labelStyle = sAmber
-			}
-			label := labelStyle.Render(truncateVisible(entry.Label, labelBudget))
-			title := dnStyle.Render(dnGlyph) + "  " + label
+		for _, j := range row {
+			cards = append(cards, m.renderCard(j, cardW, now))
+		}
+		rows = append(rows, joinH(gap, cards...))
+		members = append(members, row)
+	}
+	return rows, members
+}
 
-			timeStr := t.Format("15:04:05")
+// renderCard draws the clock at index j as a grid card.
+func (m model) renderCard(j, cardW int, now time.Time) string {
+	innerW := cardW - 4
+	entry := m.clocks[j]
+	t := entry.At(now)
+	dnGlyph, dnStyle := daynightStyle(t.Hour())
 
-			// Meta: zone + offset, sized to the inner width.
-			offStr := formatOffset(off)
-			zoneBudget := innerW - lipgloss.Width(offStr) - 2
-			if zoneBudget < 3 {
-				zoneBudget = 3
-			}
-			meta := truncateVisible(entry.Location, zoneBudget) + "  " + offStr
+	_, off := t.Zone()
+
+	// Compose the title from raw pieces so we can size the label
+	// against the card's inner width without tripping over the glyph's
+	// ANSI escapes.
+	const glyphSlot = 3 // "X  "
+	labelBudget := innerW - glyphSlot
+	if labelBudget < 3 {
+		labelBudget = 3
+	}
+	labelStyle := sPaper
+	if j == m.cursor {
+		labelStyle = sAmber
+	}
+	label := labelStyle.Render(truncateVisible(entry.Label, labelBudget))
+	title := dnStyle.Render(dnGlyph) + "  " + label
 
-			cards = append(cards, card(cardW, j == m.cursor, title, timeStr, meta))
+	timeStr := t.Format("15:04:05")
+
+	// Availability badge, abbreviated when the full word won't fit
+	// beside the time.
+	avail := entry.AvailabilityAt(now)
+	badgeText := string(avail)
+	if len(timeStr)+1+len(badgeText) > innerW {
+		badgeText = avail.Short()
+	}
+	badge := availabilityStyle(avail).Render(badgeText)
+
+	// Meta: zone + offset, sized to the inner width.
+	offStr := store.FormatOffset(off)
+	zoneBudget := innerW - lipgloss.Width(offStr) - 2
+	if zoneBudget < 3 {
+		zoneBudget = 3
+	}
+	meta := sDim.Render(truncateVisible(entry.Location, zoneBudget) + "  " + offStr)
+
+	var dial string
+	if m.layout == layoutDials {
+		dial = renderDial(t, dialCols, dialRows, false)
+	}
+
+	// An unknown zone gets no time at all rather than local time under the
+	// wrong label; the dial slot is left blank so the row stays aligned.
+	if _, err := entry.Zone(); err != nil {
+		title = sCrit.Render("⚠") + "  " + label
+		meta = sCrit.Render(truncateVisible(entry.Location, innerW))
+		if dial != "" {
+			dial = strings.Repeat("\n", dialRows-1)
 		}
-		rows = append(rows, joinH(gap, cards...))
-		i = end
+		return card(cardW, j == m.cursor, true, title, dial, sCrit.Render("UNKNOWN ZONE"), "", meta)
 	}
+	return card(cardW, j == m.cursor, false, title, dial, sBigDigit.Render(timeStr), badge, meta)
+}
 
-	return section("01", "DASHBOARD", strings.Join(rows, "\n"), m.width)
+// renderScrubBanner announces that the grid shows a shifted time rather than
+// the live one.
+func (m model) renderScrubBanner(now time.Time) string {
+	local := now.Local()
+	return horiz(
+		sHot.Render("◆ TIME TRAVEL "+formatShift(m.shift)),
+		sDim.Render("LOCAL ")+sValue.Render(local.Format("15:04 Mon 02 Jan")),
+		sFooterKey.Render("[N]")+sFooterText.Render("·LIVE"),
+	)
+}
+
+// formatShift renders a scrub offset as "+1d 02h15m" / "-45m".
+func formatShift(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	mins := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%s%dd %02dh%02dm", sign, days, hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%s%dh%02dm", sign, hours, mins)
+	default:
+		return fmt.Sprintf("%s%dm", sign, mins)
+	}
 }
 
 // --- Detail view ------------------------------------------------------------
@@ -498,29 +895,55 @@ func (m model) renderDetail() string {
 		return section("01", "DETAIL", sCrit.Render("invalid selection"), m.width)
 	}
 	entry := m.clocks[m.cursor]
-	t := entry.Now()
+	now := m.now()
+	t := entry.At(now)
 
-	// Compose: label · zone · offset · day/night on one line; big time; big ms; date.
+	// Compose: label · zone · offset · day/night · availability on one line;
+	// big time; big ms; date.
 	zoneName, off := t.Zone()
 	dnGlyph, dnStyle := daynightStyle(t.Hour())
+	avail := entry.AvailabilityAt(now)
 
+	if _, err := entry.Zone(); err != nil {
+		return section(fmt.Sprintf("%02d", m.cursor+1), "DETAIL", renderUnknownZone(entry), m.width)
+	}
 	header := horiz(
 		sAmber.Render(strings.ToUpper(entry.Label)),
 		sValue.Render(entry.Location),
-		sValue.Render(zoneName+" "+formatOffset(off)),
+		sValue.Render(zoneName+" "+store.FormatOffset(off)),
 		dnStyle.Render(dnGlyph),
+		availabilityStyle(avail).Render(string(avail)),
 	)
 
 	timeStr := t.Format("15:04:05")
 	big := renderBigText(timeStr)
+	if lipgloss.Width(big) > m.width-4 {
+		big = sBigDigit.Render(timeStr)
+	}
 	ms := sMs.Render(fmt.Sprintf(".%03d", t.Nanosecond()/int(time.Millisecond)))
+	clock := big + "\n\n" + sPaper.Render(t.Format("Monday, 02 January 2006")) + "   " + ms
+
+	// The dial goes beside the digits when it fits, with the digits
+	// centred against it.
+	if lipgloss.Width(clock)+4+detailDialCols <= m.width-4 {
+		pad := max(0, (detailDialRows-lipgloss.Height(clock))/2)
+		clock = joinH(4, renderDial(t, detailDialCols, detailDialRows, true), strings.Repeat("\n", pad)+clock)
+	}
 
 	lines := []string{
 		header,
 		"",
-		big,
-		"",
-		sPaper.Render(t.Format("Monday, 02 January 2006")) + "   " + ms,
+		clock,
+	}
+	if status := entry.StatusAt(now); status != "" {
+		note := strings.ToUpper(status)
+		if entry.Until != "" {
+			note += " until " + entry.Until
+		}
+		lines = append(lines, "", labelValue("STATUS", sHot.Render(note), 12))
+	}
+	if m.shift != 0 {
+		lines = append([]string{m.renderScrubBanner(now), ""}, lines...)
 	}
 	body := strings.Join(lines, "\n")
 	return section(fmt.Sprintf("%02d", m.cursor+1), "DETAIL", body, m.width)
@@ -534,24 +957,50 @@ func (m model) renderLabelInput() string {
 	body := sPromptMark.Render("❯ ") + m.textInput.View() + "\n\n" +
 		sDim.Render("Type a label for the clock, then press ↵ to pick a timezone. ") +
 		sDim.Render("Esc to cancel.")
-	return section("01", "ADD CLOCK · LABEL", body, m.width)
+	return section("01", m.flowTitle()+" · LABEL", body, m.width)
+}
+
+// flowTitle names the label → zone → confirm flow in section headers.
+func (m model) flowTitle() string {
+	if m.editing {
+		return "EDIT CLOCK"
+	}
+	return "ADD CLOCK"
 }
 
 func (m model) renderZonePicker() string {
-	return section("01", "ADD CLOCK · TIMEZONE", m.zoneList.View(), m.width)
+	return section("01", m.flowTitle()+" · TIMEZONE", m.zoneList.View(), m.width)
 }
 
 func (m model) renderConfirmAdd() string {
-	body := strings.Join([]string{
-		sPaper.Render("Add this clock?"),
+	question := "Add this clock?"
+	label := sValue.Render(m.newEntry.Label)
+	zone := sValue.Render(m.newEntry.Location)
+	if m.editing && m.editIndex < len(m.clocks) {
+		question = "Save changes to this clock?"
+		old := m.clocks[m.editIndex]
+		if old.Label != m.newEntry.Label {
+			label = sDim.Render(old.Label+" → ") + label
+		}
+		if old.Location != m.newEntry.Location {
+			zone = sDim.Render(old.Location+" → ") + zone
+		}
+	}
+	lines := []string{
+		sPaper.Render(question),
 		"",
-		labelValue("LABEL", sValue.Render(m.newEntry.Label), 12),
-		labelValue("ZONE", sValue.Render(m.newEntry.Location), 12),
+		labelValue("LABEL", label, 12),
+		labelValue("ZONE", zone, 12),
+	}
+	if m.newEntry.Group != "" {
+		lines = append(lines, labelValue("GROUP", sValue.Render(m.newEntry.Group), 12))
+	}
+	body := strings.Join(append(lines,
 		"",
-		sFooterKey.Render("[Y]") + sFooterText.Render(" confirm   ") +
-			sFooterKey.Render("[N]") + sFooterText.Render(" cancel"),
-	}, "\n")
-	return section("01", "ADD CLOCK · CONFIRM", body, m.width)
+		sFooterKey.Render("[Y]")+sFooterText.Render(" confirm   ")+
+			sFooterKey.Render("[N]")+sFooterText.Render(" cancel"),
+	), "\n")
+	return section("01", m.flowTitle()+" · CONFIRM", body, m.width)
 }
 
 func (m model) renderConfirmDelete() string {
@@ -573,42 +1022,98 @@ func (m model) renderConfirmDelete() string {
 
 // --- Footer -----------------------------------------------------------------
 
-func (m model) renderFooter() string {
-	var keys []string
+// hint is one footer key. When the footer doesn't fit, the hint with the
+// highest drop rank goes first; rank 0 is never dropped.
+type hint struct {
+	key, text string
+	drop      int
+}
+
+func (h hint) String() string {
+	return sFooterKey.Render(h.key) + sFooterText.Render("·"+h.text)
+}
+
+func (m model) footerHints() []hint {
 	switch m.state {
 	case viewLabelInput:
-		keys = []string{
-			sFooterKey.Render("[↵]") + sFooterText.Render("·NEXT"),
-			sFooterKey.Render("[ESC]") + sFooterText.Render("·CANCEL"),
-		}
+		return []hint{{"[↵]", "NEXT", 0}, {"[ESC]", "CANCEL", 0}}
 	case viewZonePicker:
-		keys = []string{
-			sFooterKey.Render("[/]") + sFooterText.Render("·FILTER"),
-			sFooterKey.Render("[↵]") + sFooterText.Render("·PICK"),
-			sFooterKey.Render("[ESC]") + sFooterText.Render("·BACK"),
-		}
+		return []hint{{"[/]", "FILTER", 1}, {"[↵]", "PICK", 0}, {"[ESC]", "BACK", 0}}
 	case viewConfirmAdd, viewConfirmDelete:
-		keys = []string{
-			sFooterKey.Render("[Y/N]") + sFooterText.Render("·CONFIRM"),
+		return []hint{{"[Y/N]", "CONFIRM", 0}}
+	case viewPlanner:
+		return []hint{
+			{"[← →]", "SLOT", 1},
+			{"[↑↓]", "CLOCK", 2},
+			{"[SPACE]", "IN/OUT", 3},
+			{"[*]", "ALL", 4},
+			{"[ESC]", "BACK", 0},
+			{"[Q]", "QUIT", 0},
 		}
 	case viewDetail:
-		keys = []string{
-			sFooterKey.Render("[← →]") + sFooterText.Render("·SWITCH"),
-			sFooterKey.Render("[ESC]") + sFooterText.Render("·BACK"),
-			sFooterKey.Render("[Q]") + sFooterText.Render("·QUIT"),
+		return []hint{{"[← →]", "SWITCH", 1}, {"[ESC]", "BACK", 0}, {"[Q]", "QUIT", 0}}
+	}
+
+	// Dashboard: the contextual extras go first, then scrub and edit, and
+	// the keys to move, add and quit stay.
+	keys := []hint{
+		{"[↑↓← →]", "NAV", 0},
+		{"[SHIFT+ARR]", "REORDER", 6},
+		{"[↵]", "DETAIL", 3},
+		{"[P]", "PLAN", 4},
+		{"[A]", "ADD", 0},
+		{"[E]", "EDIT", 7},
+		{"[D]", "DEL", 5},
+		{"[[ ]]", "SCRUB", 8},
+	}
+	if m.grouped() {
+		keys = append(keys, hint{"[C]", "FOLD", 10})
+	}
+	if m.width >= compactBelow {
+		next := (m.layout + 1) % layoutCount
+		keys = append(keys, hint{"[V]", next.String(), 12})
+	}
+	if len(m.boardNames()) > 1 {
+		keys = append(keys, hint{"[TAB]", "BOARD", 11})
+	}
+	if n := len(m.history.undo); n > 0 {
+		keys = append(keys, hint{"[U]", "UNDO " + truncateVisible(m.history.undo[n-1].action, 18), 9})
+	}
+	if n := len(m.history.redo); n > 0 {
+		keys = append(keys, hint{"[^R]", "REDO " + truncateVisible(m.history.redo[n-1].action, 18), 10})
+	}
+	return append(keys, hint{"[Q]", "QUIT", 0})
+}
+
+func (m model) renderFooter() string {
+	keys := m.footerHints()
+	join := func() string {
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k.String()
 		}
-	default:
-		keys = []string{
-			sFooterKey.Render("[↑↓← →]") + sFooterText.Render("·NAV"),
-			sFooterKey.Render("[SHIFT+ARR]") + sFooterText.Render("·REORDER"),
-			sFooterKey.Render("[↵]") + sFooterText.Render("·DETAIL"),
-			sFooterKey.Render("[A]") + sFooterText.Render("·ADD"),
-			sFooterKey.Render("[D]") + sFooterText.Render("·DEL"),
-			sFooterKey.Render("[Q]") + sFooterText.Render("·QUIT"),
+		return " " + strings.Join(parts, "   ")
+	}
+	right := sDim.Render(fmt.Sprintf(" uptime · %s ", m.clock.Now().Sub(m.started).Truncate(time.Second)))
+
+	// Narrow terminals: drop the uptime first, then the least important keys.
+	left := join()
+	if lipgloss.Width(left)+lipgloss.Width(right) > m.width {
+		right = ""
+	}
+	for lipgloss.Width(left) > m.width {
+		drop := -1
+		for i, k := range keys {
+			if k.drop > 0 && (drop < 0 || k.drop > keys[drop].drop) {
+				drop = i
+			}
 		}
+		if drop < 0 {
+			break
+		}
+		keys = append(keys[:drop], keys[drop+1:]...)
+		left = join()
 	}
-	left := " " + strings.Join(keys, "   ")
-	right := sDim.Render(fmt.Sprintf(" uptime · %s ", time.Since(m.started).Truncate(time.Second)))
 	pad := m.width - lipgloss.Width(left) - lipgloss.Width(right)
 	if pad < 1 {
 		pad = 1
@@ -616,22 +1121,16 @@ func (m model) renderFooter() string {
 	return left + strings.Repeat(" ", pad) + right
 }
 
-func formatOffset(off int) string {
-	sign := "+"
-	if off < 0 {
-		sign = "-"
-		off = -off
-	}
-	h := off / 3600
-	min := (off % 3600) / 60
-	return fmt.Sprintf("UTC%s%02d:%02d", sign, h, min)
-}
-
 // --- entry point ------------------------------------------------------------
 
-// Start launches the TUI.
+// Start launches the TUI. An unknown cfg.Theme is an error; theme files that
+// fail to load are reported in the masthead instead.
 func Start(cfg Config) error {
-	p := tea.NewProgram(newModel(cfg), tea.WithAltScreen())
+	m := newModel(cfg)
+	if cfg.Theme != "" && findTheme(m.themes, cfg.Theme) < 0 {
+		return fmt.Errorf("unknown theme %q (available: %s)", cfg.Theme, themeNames(m.themes))
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithReportFocus())
 	_, err := p.Run()
 	return err
 }
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+)
+
+// unknownZones picks the unresolvable locations out of cfg's problems.
+func unknownZones(cfg store.Config) []store.Problem {
+	var out []store.Problem
+	for _, p := range cfg.Check() {
+		if p.Field == "location" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// zoneWarning is the masthead line for m.badZones: each clock with its
+// suggested fix while there are only a couple, a count past that.
+func (m model) zoneWarning() string {
+	switch n := len(m.badZones); {
+	case n == 0:
+		return ""
+	case n > 2:
+		return fmt.Sprintf("UNKNOWN ZONES on %d clocks — run atlas.clock doctor for the list", n)
+	}
+	var msgs []string
+	for _, p := range m.badZones {
+		msg := fmt.Sprintf("UNKNOWN ZONE %q for %s on %s", p.Value, p.Entry.Label, p.Board)
+		if p.Suggestion != "" {
+			msg += " — did you mean " + p.Suggestion + "?"
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "  ·  ")
+}
+
+// renderUnknownZone is the detail view of a clock whose location doesn't
+// resolve: what's wrong, the likely fix and how to make it, in place of a
+// time that would only be local time under the wrong label.
+func renderUnknownZone(entry store.Entry) string {
+	lines := []string{
+		horiz(
+			sAmber.Render(strings.ToUpper(entry.Label)),
+			sCrit.Render(entry.Location),
+		),
+		"",
+		sCrit.Render(fmt.Sprintf("⚠ UNKNOWN ZONE — there is no zoneinfo for %q", entry.Location)),
+		"",
+	}
+	if s := store.SuggestZone(entry.Location); s != "" {
+		lines = append(lines, labelValue("DID YOU MEAN", sHot.Render(s), 14))
+	}
+	lines = append(lines, sFooterKey.Render("[E]")+sFooterText.Render(" edit this clock to fix it"))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/fezcode/atlas.clock/pkg/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestMain(m *testing.M) {
+	// Golden output must not depend on the machine running the tests: no
+	// color, and a fixed local zone for the "Local" clock and the masthead.
+	lipgloss.SetColorProfile(termenv.Ascii)
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// newTestModel builds the UI on the named config in testdata, frozen at the
+// RFC 3339 instant at, in a w×h terminal.
+func newTestModel(t *testing.T, config, at string, w, h int) model {
+	t.Helper()
+	t.Setenv(store.EnvConfig, filepath.Join("testdata", config))
+	instant, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel(Config{Version: "test", Clock: store.FixedClock(instant)})
+	if m.loadErr != "" {
+		t.Fatalf("load testdata/%s: %s", config, m.loadErr)
+	}
+	next, _ := m.Update(tea.WindowSizeMsg{Width: w, Height: h})
+	return next.(model)
+}
+
+// press feeds keys to m by name: "enter", "esc", arrows and "tab", or
+// anything else as typed runes. Commands are not run.
+func press(m model, keys ...string) model {
+	special := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab,
+		"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+	}
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if typ, ok := special[k]; ok {
+			msg = tea.KeyMsg{Type: typ}
+		}
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+	return m
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./pkg/ui -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("View() differs from %s (run go test ./pkg/ui -update to accept):\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+func TestView(t *testing.T) {
+	const (
+		summer = "2026-07-15T09:30:00Z"
+		// Central Europe springs forward at 01:00 UTC on 29 March 2026.
+		beforeEUSpring = "2026-03-29T00:59:30Z"
+		afterEUSpring  = "2026-03-29T01:00:30Z"
+		// New York falls back at 06:00 UTC on 1 November 2026, repeating
+		// the 01:00 hour.
+		beforeUSFall = "2026-11-01T05:59:30Z"
+		afterUSFall  = "2026-11-01T06:00:30Z"
+	)
+	tests := []struct {
+		name   string
+		config string // in testdata; clock.json if empty
+		at     string
+		w, h   int
+		cursor int
+		keys   []string
+	}{
+		{name: "dashboard", at: summer, w: 100, h: 44},
+		{name: "dashboard_before_eu_spring", at: beforeEUSpring, w: 100, h: 44},
+		{name: "dashboard_after_eu_spring", at: afterEUSpring, w: 100, h: 44},
+		{name: "dashboard_before_us_fall", at: beforeUSFall, w: 100, h: 44},
+		{name: "dashboard_after_us_fall", at: afterUSFall, w: 100, h: 44},
+		{name: "dashboard_scrolled", at: summer, w: 100, h: 24, cursor: 7},
+		{name: "dashboard_scrubbed", at: summer, w: 100, h: 44, keys: []string{"}", "}", "]"}},
+		{name: "dashboard_folded", at: summer, w: 100, h: 44, cursor: 3, keys: []string{"c"}},
+		{name: "dashboard_dials", at: summer, w: 100, h: 44, keys: []string{"v"}},
+		{name: "dashboard_list", at: summer, w: 100, h: 30, keys: []string{"v", "v"}},
+		{name: "dashboard_narrow", at: summer, w: 48, h: 30},
+		{name: "dashboard_second_board", at: summer, w: 100, h: 30, keys: []string{"tab"}},
+		{name: "detail", at: summer, w: 100, h: 30, cursor: 1, keys: []string{"enter"}},
+		{name: "detail_half_hour", at: summer, w: 100, h: 30, cursor: 3, keys: []string{"enter"}},
+		{name: "detail_quarter_hour", at: summer, w: 100, h: 30, cursor: 4, keys: []string{"enter"}},
+		{name: "detail_scrubbed", at: summer, w: 100, h: 34, cursor: 1, keys: []string{"}", "enter"}},
+		{name: "detail_us_fall_repeat", at: afterUSFall, w: 100, h: 30, cursor: 6, keys: []string{"enter"}},
+		{name: "label_input", at: summer, w: 100, h: 30, keys: []string{"a", "Lima"}},
+		{name: "zone_picker", at: summer, w: 100, h: 30, keys: []string{"a", "Lima", "enter", "down", "down"}},
+		{name: "confirm_add", at: summer, w: 100, h: 30, keys: []string{"a", "Lima", "enter", "down", "down", "enter"}},
+		{name: "confirm_delete", at: summer, w: 100, h: 30, cursor: 2, keys: []string{"d"}},
+		{name: "planner", at: summer, w: 100, h: 40, keys: []string{"p"}},
+		{name: "planner_scrubbed", at: summer, w: 100, h: 44, keys: []string{"}", "p"}},
+		{name: "planner_half_hour", at: summer, w: 100, h: 40, cursor: 3, keys: []string{"p", "right", "right"}},
+		{name: "unknown_zone", config: "unknown.json", at: summer, w: 100, h: 20},
+		{name: "unknown_zone_list", config: "unknown.json", at: summer, w: 100, h: 20, keys: []string{"v", "v"}},
+		{name: "unknown_zone_detail", config: "unknown.json", at: summer, w: 100, h: 20, keys: []string{"enter"}},
+		{name: "unknown_zone_planner", config: "unknown.json", at: summer, w: 100, h: 30, keys: []string{"p"}},
+		{name: "unknown_zone_edit", config: "unknown.json", at: summer, w: 100, h: 30, keys: []string{"e", "enter"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config == "" {
+				config = "clock.json"
+			}
+			m := newTestModel(t, config, tt.at, tt.w, tt.h)
+			m.cursor = tt.cursor
+			m.followCursor()
+			m = press(m, tt.keys...)
+			assertGolden(t, tt.name, m.View())
+		})
+	}
+}